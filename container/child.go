@@ -0,0 +1,102 @@
+package container
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// NewChild returns a container that resolves the beans it does not define
+// from c. Beans registered in the child shadow those of c with the same name
+// or type. Singletons are cached in the container defining them, and resolve
+// their dependencies from it, so beans of c are shared by all its children
+// while the child's own beans live and are closed with the child.
+func (c *Container) NewChild() *Container {
+	return &Container{
+		mu:        c.mu,
+		parent:    c,
+		infos:     make(map[string][]BeanInfo),
+		types:     make(map[string][]string),
+		factories: make(map[string]Constructor),
+		beans:     make(map[string]any),
+		failed:    make(map[string]error),
+		calls:     make(map[string]*call),
+	}
+}
+
+// find returns the definition of a bean visible from c by name, or by type
+// name for beans registered with a Type, with the container defining it.
+// Conditions are evaluated with the profiles of the defining container, so
+// that the singletons it caches do not depend on who resolves them. Must be
+// called with c.mu held.
+func (c *Container) find(name string) (*Container, BeanInfo, error) {
+	var errNoMatch error
+	for x := c; x != nil; x = x.parent {
+		profiles := x.activeProfiles()
+		if defs, ok := x.infos[name]; ok {
+			beanInfo, ok, err := match(name, defs, profiles)
+			if err != nil {
+				return nil, BeanInfo{}, err
+			}
+			if ok {
+				return x, beanInfo, nil
+			}
+			// none of the definitions matches, fall back to the parent
+			if errNoMatch == nil {
+				errNoMatch = &Error{Kind: ErrNotFound, Name: name, msg: fmt.Sprintf("no definition of bean %s matches, active profiles: %v", name, profiles)}
+			}
+			continue
+		}
+
+		var found []BeanInfo
+		for _, n := range x.types[name] {
+			beanInfo, ok, _ := match(n, x.infos[n], profiles)
+			if ok && beanInfo.Type != nil && typeName(beanInfo.Type) == name {
+				found = append(found, beanInfo)
+			}
+		}
+		switch len(found) {
+		case 0:
+		case 1:
+			return x, found[0], nil
+		default:
+			names := make([]string, len(found))
+			for i, beanInfo := range found {
+				names[i] = beanInfo.Name
+			}
+			return nil, BeanInfo{}, &Error{Kind: ErrAmbiguous, Name: name, Candidates: names, msg: fmt.Sprintf("ambiguous bean %s: %s", name, strings.Join(names, ", "))}
+		}
+	}
+	if errNoMatch != nil {
+		return nil, BeanInfo{}, errNoMatch
+	}
+	return nil, BeanInfo{}, &Error{Kind: ErrNotFound, Name: name}
+}
+
+// lookup is find without the defining container.
+func (c *Container) lookup(name string) (BeanInfo, error) {
+	_, beanInfo, err := c.find(name)
+	return beanInfo, err
+}
+
+// names returns the sorted names of the beans defined in c or its parents.
+// Must be called with c.mu held.
+func (c *Container) names() []string {
+	names := make(map[string]struct{})
+	for x := c; x != nil; x = x.parent {
+		for name := range x.infos {
+			names[name] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// holder returns the container that caches a bean defined in owner when it
+// is resolved from c, and that resolves its dependencies.
+func (c *Container) holder(owner *Container, beanInfo BeanInfo) *Container {
+	if beanInfo.Scope == ScopeSingleton {
+		return owner
+	}
+	return c
+}
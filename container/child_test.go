@@ -0,0 +1,130 @@
+package container
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type childTestSuit struct {
+	suite.Suite
+}
+
+func TestChild(t *testing.T) {
+	suite.Run(t, new(childTestSuit))
+}
+
+type named struct {
+	name    string
+	depends map[string]any
+	closed  bool
+}
+
+func (n *named) Close() error {
+	n.closed = true
+	return nil
+}
+
+func (t *childTestSuit) register(c *Container, name, value string, depends ...string) {
+	err := c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: depends,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &named{name: value, depends: depends}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+}
+
+func (t *childTestSuit) TestParentLookup() {
+	parent := New()
+	t.register(parent, "db", "parent db")
+	child := parent.NewChild()
+	t.register(child, "service", "child service", "db")
+
+	s, err := Get[*named](child, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	db, err := Get[*named](parent, "db")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Same(db, s.depends["db"], "parent beans should be shared")
+	t.Assertions.Contains(child.beans, "service", "child beans should be cached in the child")
+	t.Assertions.NotContains(parent.beans, "service", "child beans should not leak into the parent")
+
+	_, err = parent.Get("service")
+	t.Assertions.Error(err, "parent should not see child beans")
+}
+
+func (t *childTestSuit) TestOverride() {
+	parent := New()
+	t.register(parent, "config", "parent config")
+	t.register(parent, "db", "parent db", "config")
+	child := parent.NewChild()
+	t.register(child, "config", "child config")
+	t.register(child, "service", "child service", "config", "db")
+
+	s, err := Get[*named](child, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("child config", s.depends["config"].(*named).name, "child beans should shadow parent beans")
+
+	db := s.depends["db"].(*named)
+	t.Assertions.Equal("parent config", db.depends["config"].(*named).name, "parent singletons should resolve from the parent")
+}
+
+func (t *childTestSuit) TestSiblings() {
+	parent := New()
+	t.register(parent, "db", "parent db")
+	tenant1 := parent.NewChild()
+	tenant2 := parent.NewChild()
+	t.register(tenant1, "service", "tenant1", "db")
+	t.register(tenant2, "service", "tenant2", "db")
+
+	s1, err := Get[*named](tenant1, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	s2, err := Get[*named](tenant2, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("tenant1", s1.name)
+	t.Assertions.Equal("tenant2", s2.name)
+	t.Assertions.Same(s1.depends["db"], s2.depends["db"], "siblings should share parent beans")
+}
+
+func (t *childTestSuit) TestClose() {
+	parent := New()
+	t.register(parent, "db", "parent db")
+	child := parent.NewChild()
+	t.register(child, "service", "child service", "db")
+
+	s, err := Get[*named](child, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NoError(child.Close(context.Background()), "Close() should not return error")
+	t.Assertions.True(s.closed, "child beans should be closed")
+	t.Assertions.False(s.depends["db"].(*named).closed, "parent beans should not be closed")
+}
+
+func (t *childTestSuit) TestValidateAndGraph() {
+	parent := New()
+	t.register(parent, "db", "parent db")
+	child := parent.NewChild()
+	t.register(child, "service", "child service", "db", "missing")
+
+	t.Assertions.NoError(parent.Validate(), "Validate() should only check visible beans")
+	t.Assertions.EqualError(child.Validate(), "bean service depends on missing: bean not found: missing")
+
+	g := child.Graph()
+	t.Assertions.Equal([]GraphEdge{{From: "service", To: "db"}, {From: "service", To: "missing"}}, g.Edges)
+}
+
+func (t *childTestSuit) TestLoadConfigWithParentFactory() {
+	parent := New()
+	err := parent.RegisterFactory("named", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return &named{name: params["name"].(string)}, nil
+	})
+	t.Assertions.NoError(err, "RegisterFactory() should not return error")
+
+	child := parent.NewChild()
+	t.Assertions.NoError(child.LoadConfig(strings.NewReader(`{"beans": [{"name": "db", "factory": "named", "params": {"name": "child db"}}]}`), ConfigFormatJSON))
+	db, err := Get[*named](child, "db")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("child db", db.name)
+}
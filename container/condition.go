@@ -0,0 +1,106 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Condition decides whether a bean definition applies, given the active
+// profiles of the container defining it. Conditions are evaluated on every
+// lookup of the bean, including Validate, Graph and Health, with the
+// container locked: they must be cheap and must not call the container,
+// which would deadlock.
+type Condition func(profiles []string) bool
+
+// OnProfile holds when any of profiles is active.
+func OnProfile(profiles ...string) Condition {
+	return func(active []string) bool {
+		for _, p := range profiles {
+			if slices.Contains(active, p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OnEnv holds when the environment variable key is set.
+func OnEnv(key string) Condition {
+	return func([]string) bool {
+		_, ok := os.LookupEnv(key)
+		return ok
+	}
+}
+
+// OnEnvValue holds when the environment variable key is set to value.
+func OnEnvValue(key, value string) Condition {
+	return func([]string) bool {
+		v, ok := os.LookupEnv(key)
+		return ok && v == value
+	}
+}
+
+// When holds when predicate returns true. predicate is evaluated on every
+// lookup and must not call the container, see Condition.
+func When(predicate func() bool) Condition {
+	return func([]string) bool {
+		return predicate()
+	}
+}
+
+// SetProfiles sets the active profiles of c and of its children that do not
+// set their own.
+func (c *Container) SetProfiles(profiles ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiles = slices.Clone(profiles)
+	if c.profiles == nil {
+		c.profiles = []string{}
+	}
+}
+
+func (c *Container) Profiles() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.activeProfiles())
+}
+
+// activeProfiles returns the profiles of c or, if it has none set, of its
+// closest parent with profiles. Must be called with c.mu held.
+func (c *Container) activeProfiles() []string {
+	for x := c; x != nil; x = x.parent {
+		if x.profiles != nil {
+			return x.profiles
+		}
+	}
+	return nil
+}
+
+// match returns the single definition among defs whose conditions all hold.
+// It reports false if none does and an error if several do.
+func match(name string, defs []BeanInfo, profiles []string) (BeanInfo, bool, error) {
+	var matched []BeanInfo
+	for _, beanInfo := range defs {
+		if beanInfo.matches(profiles) {
+			matched = append(matched, beanInfo)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return BeanInfo{}, false, nil
+	case 1:
+		return matched[0], true, nil
+	default:
+		return BeanInfo{}, false, &Error{Kind: ErrAmbiguous, Name: name, msg: fmt.Sprintf("%d definitions of bean %s match, active profiles: %v", len(matched), name, profiles)}
+	}
+}
+
+func (b BeanInfo) matches(profiles []string) bool {
+	for _, cond := range b.Conditions {
+		if !cond(profiles) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type conditionTestSuit struct {
+	suite.Suite
+}
+
+func TestCondition(t *testing.T) {
+	suite.Run(t, new(conditionTestSuit))
+}
+
+func (t *conditionTestSuit) register(c *Container, name, value string, conditions ...Condition) error {
+	return c.Register(BeanInfo{
+		Name:       name,
+		Conditions: conditions,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return value, nil
+		},
+	})
+}
+
+func (t *conditionTestSuit) TestProfiles() {
+	c := New()
+	t.Assertions.NoError(t.register(c, "store", "memory", OnProfile("dev", "test")))
+	t.Assertions.NoError(t.register(c, "store", "postgres", OnProfile("prod")))
+
+	_, err := c.Get("store")
+	t.Assertions.ErrorContains(err, "no definition of bean store matches")
+
+	c.SetProfiles("prod")
+	t.Assertions.Equal([]string{"prod"}, c.Profiles())
+	store, err := Get[string](c, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("postgres", store)
+
+	child := c.NewChild()
+	child.SetProfiles("test")
+	t.Assertions.NoError(t.register(child, "cache", "memory", OnProfile("test")))
+	store, err = Get[string](child, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("postgres", store, "conditions should be evaluated with the profiles of the defining container")
+	cache, err := Get[string](child, "cache")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("memory", cache, "children should use their own profiles")
+}
+
+func (t *conditionTestSuit) TestSeveralMatch() {
+	c := New()
+	t.Assertions.NoError(t.register(c, "store", "memory", OnProfile("dev")))
+	t.Assertions.NoError(t.register(c, "store", "postgres", When(func() bool { return true })))
+	c.SetProfiles("dev")
+
+	_, err := c.Get("store")
+	t.Assertions.ErrorContains(err, "2 definitions of bean store match")
+	t.Assertions.ErrorContains(c.Validate(), "2 definitions of bean store match")
+}
+
+func (t *conditionTestSuit) TestEnv() {
+	t.T().Setenv("HALO_TEST_STORE", "redis")
+	c := New()
+	t.Assertions.NoError(t.register(c, "store", "memory", OnEnvValue("HALO_TEST_STORE", "memory")))
+	t.Assertions.NoError(t.register(c, "store", "redis", OnEnv("HALO_TEST_STORE"), OnEnvValue("HALO_TEST_STORE", "redis")))
+	t.Assertions.NoError(t.register(c, "store", "postgres", OnEnv("HALO_TEST_UNSET")))
+
+	store, err := Get[string](c, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("redis", store)
+}
+
+func (t *conditionTestSuit) TestWhen() {
+	c := New()
+	postgres, locked, calls := false, true, 0
+	t.Assertions.NoError(t.register(c, "store", "memory", When(func() bool {
+		calls++
+		if c.mu.TryLock() {
+			locked = false
+			c.mu.Unlock()
+		}
+		return !postgres
+	})))
+	t.Assertions.NoError(t.register(c, "store", "postgres", When(func() bool { return postgres })))
+
+	store, err := Get[string](c, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("memory", store)
+	t.Assertions.True(locked, "predicates should be evaluated with the container locked")
+
+	postgres = true
+	t.Assertions.NoError(c.Validate(), "Validate() should not return error")
+	t.Assertions.Equal(2, calls, "Validate() should evaluate the predicates")
+}
+
+func (t *conditionTestSuit) TestRegisterUnconditionalTwice() {
+	c := New()
+	t.Assertions.NoError(t.register(c, "store", "memory", OnProfile("dev")))
+	t.Assertions.Error(t.register(c, "store", "postgres"), "Register() should reject an unconditional duplicate")
+
+	c = New()
+	t.Assertions.NoError(t.register(c, "store", "postgres"))
+	t.Assertions.Error(t.register(c, "store", "memory", OnProfile("dev")), "Register() should reject a duplicate of an unconditional bean")
+}
+
+func (t *conditionTestSuit) TestFallbackToParent() {
+	parent := New()
+	t.Assertions.NoError(t.register(parent, "store", "parent"))
+	child := parent.NewChild()
+	t.Assertions.NoError(t.register(child, "store", "child", OnProfile("tenant")))
+
+	store, err := Get[string](child, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("parent", store, "unmatched definitions should fall back to the parent")
+}
+
+func (t *conditionTestSuit) TestProvideByType() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() *db { return &db{dsn: "memory"} }, WithName("memory"), WithConditions(OnProfile("dev"))))
+	t.Assertions.NoError(c.Provide(func() *db { return &db{dsn: "postgres"} }, WithName("postgres"), WithConditions(OnProfile("prod"))))
+	t.Assertions.NoError(c.Provide(newCache))
+	c.SetProfiles("dev")
+
+	cc, err := Get[*cache](c, typeName(reflect.TypeOf(&cache{})))
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("memory", cc.db.dsn, "only matching definitions should be resolved by type")
+}
+
+func (t *conditionTestSuit) TestConfigProfiles() {
+	c := New()
+	t.Assertions.NoError(c.RegisterFactory("string", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return params["value"], nil
+	}))
+	err := c.LoadConfig(strings.NewReader(`
+beans:
+  - {name: store, factory: string, profiles: [dev], params: {value: memory}}
+  - {name: store, factory: string, profiles: [prod], params: {value: postgres}}
+`), ConfigFormatYAML)
+	t.Assertions.NoError(err, "LoadConfig() should not return error")
+	c.SetProfiles("prod")
+
+	store, err := Get[string](c, "store")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("postgres", store)
+}
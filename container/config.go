@@ -0,0 +1,228 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigFormat string
+
+const (
+	ConfigFormatJSON ConfigFormat = "json"
+	ConfigFormatYAML ConfigFormat = "yaml"
+)
+
+// Config is the file representation of a set of beans.
+type Config struct {
+	Beans []BeanConfig `json:"beans" yaml:"beans"`
+}
+
+// BeanConfig declares a bean built by the factory registered under Factory.
+type BeanConfig struct {
+	Name         string         `json:"name" yaml:"name"`
+	Factory      string         `json:"factory" yaml:"factory"`
+	Dependencies []string       `json:"dependencies" yaml:"dependencies"`
+	Params       map[string]any `json:"params" yaml:"params"`
+	Scope        Scope          `json:"scope" yaml:"scope"`
+	// Profiles restricts the bean to containers with any of them active.
+	Profiles []string `json:"profiles" yaml:"profiles"`
+	// NonCritical marks a bean whose health check failures only degrade the
+	// container.
+	NonCritical bool `json:"non_critical" yaml:"non_critical"`
+
+	Groups            []string `json:"groups" yaml:"groups"`
+	Priority          int      `json:"priority" yaml:"priority"`
+	GroupDependencies []string `json:"group_dependencies" yaml:"group_dependencies"`
+
+	OptionalDependencies []string          `json:"optional_dependencies" yaml:"optional_dependencies"`
+	Fallbacks            map[string]string `json:"fallbacks" yaml:"fallbacks"`
+}
+
+// RegisterFactory makes constructor available to config files under key.
+func (c *Container) RegisterFactory(key string, constructor Constructor) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.factories[key]; ok {
+		return errors.New("factory already exists: " + key)
+	}
+	if constructor == nil {
+		return errors.New("constructor is required")
+	}
+	c.factories[key] = constructor
+	return nil
+}
+
+// LoadConfigFile loads a JSON or YAML config file, chosen by its extension,
+// see LoadConfig.
+func (c *Container) LoadConfigFile(path string) error {
+	var format ConfigFormat
+	switch filepath.Ext(path) {
+	case ".json":
+		format = ConfigFormatJSON
+	case ".yaml", ".yml":
+		format = ConfigFormatYAML
+	default:
+		return fmt.Errorf("unknown config format: %s", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := c.LoadConfig(f, format); err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadConfig registers the beans declared in r. String params may refer to
+// environment variables as ${NAME} or ${NAME:-default}; the default is used
+// when the variable is unset or empty. Nothing is registered if any bean
+// refers to an unknown factory or an unset variable without default.
+func (c *Container) LoadConfig(r io.Reader, format ConfigFormat) error {
+	var config Config
+	switch format {
+	case ConfigFormatJSON:
+		if err := json.NewDecoder(r).Decode(&config); err != nil {
+			return err
+		}
+	case ConfigFormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&config); err != nil && err != io.EOF {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown config format: %s", format)
+	}
+
+	infos := make([]BeanInfo, 0, len(config.Beans))
+	var errs []error
+	for _, bc := range config.Beans {
+		beanInfo, err := c.beanInfo(bc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		infos = append(infos, beanInfo)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, beanInfo := range infos {
+		if err := c.Register(beanInfo); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// factory finds a factory registered in c or its parents. Must be called
+// with c.mu held.
+func (c *Container) factory(key string) (Constructor, bool) {
+	for ; c != nil; c = c.parent {
+		if constructor, ok := c.factories[key]; ok {
+			return constructor, true
+		}
+	}
+	return nil, false
+}
+
+func (c *Container) beanInfo(bc BeanConfig) (BeanInfo, error) {
+	if bc.Name == "" {
+		return BeanInfo{}, fmt.Errorf("bean with factory %s: name is required", bc.Factory)
+	}
+
+	c.mu.Lock()
+	constructor, ok := c.factory(bc.Factory)
+	c.mu.Unlock()
+	if !ok {
+		return BeanInfo{}, fmt.Errorf("bean %s: unknown factory: %s", bc.Name, bc.Factory)
+	}
+
+	params, err := expandEnv(bc.Params)
+	if err != nil {
+		return BeanInfo{}, fmt.Errorf("bean %s: %w", bc.Name, err)
+	}
+	p, _ := params.(map[string]any)
+
+	beanInfo := BeanInfo{
+		Name:         bc.Name,
+		Dependencies: bc.Dependencies,
+		Params:       p,
+		Constructor:  constructor,
+		Scope:        bc.Scope,
+		NonCritical:  bc.NonCritical,
+
+		Groups:            bc.Groups,
+		Priority:          bc.Priority,
+		GroupDependencies: bc.GroupDependencies,
+
+		OptionalDependencies: bc.OptionalDependencies,
+		Fallbacks:            bc.Fallbacks,
+	}
+	if len(bc.Profiles) > 0 {
+		beanInfo.Conditions = []Condition{OnProfile(bc.Profiles...)}
+	}
+	return beanInfo, nil
+}
+
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv substitutes environment variables in every string of v.
+func expandEnv(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		var err error
+		s := envPattern.ReplaceAllStringFunc(v, func(m string) string {
+			sub := envPattern.FindStringSubmatch(m)
+			value, set := os.LookupEnv(sub[1])
+			switch {
+			case sub[2] != "" && value == "":
+				// ${VAR:-default} applies to unset and empty variables
+				return sub[3]
+			case !set:
+				if err == nil {
+					err = fmt.Errorf("environment variable %s is not set", sub[1])
+				}
+				return m
+			default:
+				return value
+			}
+		})
+		return s, err
+	case map[string]any:
+		if v == nil {
+			return v, nil
+		}
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			expanded, err := expandEnv(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			m[key] = expanded
+		}
+		return m, nil
+	case []any:
+		s := make([]any, len(v))
+		for i, value := range v {
+			expanded, err := expandEnv(value)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			s[i] = expanded
+		}
+		return s, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,135 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type configTestSuit struct {
+	suite.Suite
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(configTestSuit))
+}
+
+type configBean struct {
+	params  map[string]any
+	depends map[string]any
+}
+
+func (t *configTestSuit) newContainer() *Container {
+	c := New()
+	err := c.RegisterFactory("bean", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return &configBean{params: params, depends: depends}, nil
+	})
+	t.Assertions.NoError(err, "RegisterFactory() should not return error")
+	return c
+}
+
+const yamlConfig = `
+beans:
+  - name: db
+    factory: bean
+    params:
+      dsn: ${HALO_TEST_DSN:-file::memory:}
+      pool: 4
+      hosts:
+        - ${HALO_TEST_HOST}:5432
+  - name: repo
+    factory: bean
+    scope: prototype
+    dependencies: [db]
+`
+
+func (t *configTestSuit) TestLoadYAML() {
+	t.T().Setenv("HALO_TEST_HOST", "localhost")
+	c := t.newContainer()
+	t.Assertions.NoError(c.LoadConfig(strings.NewReader(yamlConfig), ConfigFormatYAML), "LoadConfig() should not return error")
+
+	repo, err := Get[*configBean](c, "repo")
+	t.Assertions.NoError(err, "Get() should not return error")
+	db := repo.depends["db"].(*configBean)
+	t.Assertions.Equal(map[string]any{
+		"dsn":   "file::memory:",
+		"pool":  4,
+		"hosts": []any{"localhost:5432"},
+	}, db.params)
+	t.Assertions.Equal(ScopePrototype, c.infos["repo"][0].Scope)
+}
+
+func (t *configTestSuit) TestLoadJSONFile() {
+	t.T().Setenv("HALO_TEST_DSN", "postgres://db")
+	path := filepath.Join(t.T().TempDir(), "beans.json")
+	err := os.WriteFile(path, []byte(`{"beans": [{"name": "db", "factory": "bean", "params": {"dsn": "${HALO_TEST_DSN:-memory}", "pool": 4}}]}`), 0o600)
+	t.Assertions.NoError(err)
+
+	c := t.newContainer()
+	t.Assertions.NoError(c.LoadConfigFile(path), "LoadConfigFile() should not return error")
+
+	db, err := Get[*configBean](c, "db")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal(map[string]any{"dsn": "postgres://db", "pool": float64(4)}, db.params)
+}
+
+func (t *configTestSuit) TestExpandEnv() {
+	t.T().Setenv("HALO_TEST_EMPTY", "")
+	t.T().Setenv("HALO_TEST_SET", "value")
+
+	for in, out := range map[string]string{
+		"${HALO_TEST_EMPTY}":                 "",
+		"${HALO_TEST_EMPTY:-default}":        "default",
+		"${HALO_TEST_UNSET:-default}":        "default",
+		"${HALO_TEST_UNSET:-}":               "",
+		"${HALO_TEST_SET:-default}":          "value",
+		"[${HALO_TEST_SET}]":                 "[value]",
+		"${HALO_TEST_SET}${HALO_TEST_EMPTY}": "value",
+	} {
+		expanded, err := expandEnv(in)
+		t.Assertions.NoError(err, in)
+		t.Assertions.Equal(out, expanded, in)
+	}
+
+	_, err := expandEnv("${HALO_TEST_UNSET}")
+	t.Assertions.EqualError(err, "environment variable HALO_TEST_UNSET is not set")
+}
+
+func (t *configTestSuit) TestLoadErrors() {
+	c := t.newContainer()
+	err := c.LoadConfig(strings.NewReader(`
+beans:
+  - name: db
+    factory: bean
+  - name: cache
+    factory: redis
+  - name: repo
+    factory: bean
+    params:
+      nested:
+        dsn: ${HALO_TEST_UNSET}
+  - factory: bean
+`), ConfigFormatYAML)
+	t.Assertions.ErrorContains(err, "bean cache: unknown factory: redis")
+	t.Assertions.ErrorContains(err, "bean repo: nested: dsn: environment variable HALO_TEST_UNSET is not set")
+	t.Assertions.ErrorContains(err, "name is required")
+	t.Assertions.Empty(c.infos, "nothing should be registered")
+}
+
+func (t *configTestSuit) TestLoadUnknownFormat() {
+	c := t.newContainer()
+	t.Assertions.Error(c.LoadConfig(strings.NewReader(""), "toml"))
+	t.Assertions.Error(c.LoadConfigFile("beans.toml"))
+	t.Assertions.Error(c.LoadConfig(strings.NewReader("beans:\n  - name: db\n    scope: forever\n"), ConfigFormatYAML))
+}
+
+func (t *configTestSuit) TestRegisterFactoryTwice() {
+	c := t.newContainer()
+	err := c.RegisterFactory("bean", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return nil, nil
+	})
+	t.Assertions.Error(err, "RegisterFactory() should return error")
+}
@@ -1,79 +1,544 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Container struct {
-	infos map[string]BeanInfo
-	beans map[string]any
+	// mu is shared by a container and all of its children.
+	mu     *sync.Mutex
+	parent *Container
 
-	creating map[string]struct{}
+	// infos holds the definitions of each bean; several definitions of a
+	// name must all have Conditions.
+	infos map[string][]BeanInfo
+	// types indexes the names of beans with a known Type by type name.
+	types map[string][]string
+	// factories holds the constructors config files refer to by key.
+	factories map[string]Constructor
+	// profiles are the active profiles, inherited from the parent when nil.
+	profiles []string
+
+	decorators  map[string][]Decorator
+	beforeHooks []BeforeCreateHook
+	afterHooks  []AfterCreateHook
+	beans       map[string]any
+	// order lists the created beans, each after its dependencies.
+	order []string
+	// failed holds the last constructor error of beans that failed to build.
+	failed map[string]error
+
+	watchers map[string][]Watcher
+	// replaced holds the instances of beans dropped by Reload, until they
+	// are rebuilt and passed to the watchers.
+	replaced map[string]any
+	// traces holds the last construction of the beans created by c.
+	traces     map[string]Trace
+	traceHooks []TraceHook
+
+	// calls holds the constructions in flight, so that concurrent Get calls
+	// for the same bean wait for a single construction.
+	calls map[string]*call
+	// epoch is incremented by Close, so that constructions in flight do not
+	// cache their bean in the emptied container.
+	epoch uint64
+	// gens counts the invalidations of each bean, so that constructions in
+	// flight do not cache an outdated bean.
+	gens map[string]uint64
+	// children are the children of c holding beans, which may depend on
+	// beans of c.
+	children map[*Container]struct{}
 }
 
 func New() *Container {
 	return &Container{
-		beans:    make(map[string]any),
-		infos:    make(map[string]BeanInfo),
-		creating: make(map[string]struct{}),
+		mu:        new(sync.Mutex),
+		beans:     make(map[string]any),
+		infos:     make(map[string][]BeanInfo),
+		types:     make(map[string][]string),
+		factories: make(map[string]Constructor),
+		failed:    make(map[string]error),
+		calls:     make(map[string]*call),
 	}
 }
 
 type Constructor func(depends map[string]any, params map[string]any) (interface{}, error)
 
+// ContextConstructor is a Constructor that receives the context of the
+// GetContext call that triggered the construction.
+type ContextConstructor func(ctx context.Context, depends map[string]any, params map[string]any) (interface{}, error)
+
 type BeanInfo struct {
 	Name         string
 	Dependencies []string
 	Params       map[string]any
 	Constructor  Constructor
+	Scope        Scope
+	// Type is the type of the bean, if known. Beans with a Type can be
+	// depended on by type name, as done by Provide.
+	Type reflect.Type
+	// InjectFields fills the `inject` tagged fields of the bean once the
+	// Constructor returns, see Container.Inject.
+	InjectFields bool
+	// ContextConstructor is used instead of Constructor when set.
+	ContextConstructor ContextConstructor
+	// Timeout bounds the time spent in the constructor, if positive.
+	Timeout time.Duration
+	// Retry retries the constructor when it fails, each attempt bounded by
+	// Timeout.
+	Retry RetryPolicy
+	// LazyDependencies are passed to the constructor as a Provider[any]
+	// resolving the bean on demand, see Lazy. They do not count for cycles.
+	LazyDependencies []string
+	// OptionalDependencies are passed to the constructor like Dependencies
+	// when they exist and left out of depends otherwise.
+	OptionalDependencies []string
+	// Fallbacks maps a dependency to the bean used in its place when it does
+	// not exist. The fallback is passed under the name of the dependency.
+	Fallbacks map[string]string
+	// GroupDependencies are passed to the constructor as a []any holding
+	// every bean of the group, keyed by group name, see GetGroup.
+	GroupDependencies []string
+	// Groups are the groups the bean belongs to.
+	Groups []string
+	// Priority orders the beans of a group, lowest first.
+	Priority int
+	// Primary picks the bean among several candidates of Resolve.
+	Primary bool
+	// Conditions must all hold for the definition to be used. Several
+	// definitions can share a name if they all have conditions; exactly one
+	// of them must match when the bean is resolved.
+	Conditions []Condition
+	// HealthTimeout bounds the health check of the bean, see Container.Health.
+	HealthTimeout time.Duration
+	// NonCritical beans failing their health check degrade the container
+	// instead of bringing it down.
+	NonCritical bool
+}
+
+// call is a single construction of a bean, shared by everyone asking for it
+// while it is in flight.
+type call struct {
+	done  chan struct{}
+	bean  any
+	err   error
+	owner *resolution
+	// epoch and gen are those of the container and bean when the
+	// construction started.
+	epoch uint64
+	gen   uint64
+}
+
+// errStale is the result of a construction outdated by an invalidation while
+// in flight, which is retried.
+var errStale = errors.New("stale construction")
+
+// resolution is the state of one resolution chain, started by a Get call.
+type resolution struct {
+	ctx context.Context
+	// trigger is the bean the chain was started for.
+	trigger string
+	// path is the chain of beans being created, outermost first.
+	path []string
+	// waiting is the call this chain is blocked on, if any.
+	waiting *call
+	// singleton is the outermost singleton being created in this chain, which
+	// must not capture scoped beans.
+	singleton string
+	// parent is the chain blocked on this one, when a lazy dependency is
+	// resolved while its dependent is being constructed.
+	parent *resolution
 }
 
 func (c *Container) Register(beanInfo BeanInfo) error {
-	if _, ok := c.infos[beanInfo.Name]; ok {
-		return errors.New("bean already exists: " + beanInfo.Name)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.infos[beanInfo.Name] {
+		if len(existing.Conditions) == 0 || len(beanInfo.Conditions) == 0 {
+			return errors.New("bean already exists: " + beanInfo.Name)
+		}
+	}
+	if err := checkBeanInfo(beanInfo); err != nil {
+		return err
 	}
 
-	if beanInfo.Constructor == nil {
+	c.define(beanInfo)
+	return nil
+}
+
+func checkBeanInfo(beanInfo BeanInfo) error {
+	if beanInfo.Constructor == nil && beanInfo.ContextConstructor == nil {
 		return errors.New("constructor is required")
 	}
-
-	c.infos[beanInfo.Name] = beanInfo
+	if beanInfo.Constructor != nil && beanInfo.ContextConstructor != nil {
+		return errors.New("only one of Constructor and ContextConstructor can be set: " + beanInfo.Name)
+	}
+	if beanInfo.InjectFields && beanInfo.Type != nil {
+		return checkInjectTags(beanInfo.Type)
+	}
 	return nil
 }
 
+// define adds beanInfo to the definitions of c. Must be called with c.mu held.
+func (c *Container) define(beanInfo BeanInfo) {
+	c.infos[beanInfo.Name] = append(c.infos[beanInfo.Name], beanInfo)
+	if beanInfo.Type != nil {
+		key := typeName(beanInfo.Type)
+		if !slices.Contains(c.types[key], beanInfo.Name) {
+			c.types[key] = append(c.types[key], beanInfo.Name)
+		}
+	}
+}
+
+// undefine removes every definition of name from c. Must be called with c.mu
+// held.
+func (c *Container) undefine(name string) {
+	for _, beanInfo := range c.infos[name] {
+		if beanInfo.Type == nil {
+			continue
+		}
+		key := typeName(beanInfo.Type)
+		c.types[key] = slices.DeleteFunc(c.types[key], func(n string) bool { return n == name })
+		if len(c.types[key]) == 0 {
+			delete(c.types, key)
+		}
+	}
+	delete(c.infos, name)
+}
+
 func (c *Container) Get(name string) (interface{}, error) {
+	return c.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get, with ctx passed to every ContextConstructor in the
+// resolution chain. Construction and waiting for a bean being constructed
+// by another goroutine stop when ctx is done.
+func (c *Container) GetContext(ctx context.Context, name string) (interface{}, error) {
+	return c.get(&resolution{ctx: ctx, trigger: name}, name)
+}
+
+func (c *Container) get(r *resolution, name string) (any, error) {
+	for {
+		bean, err := c.getOnce(r, name)
+		if err != errStale {
+			return bean, err
+		}
+	}
+}
+
+func (c *Container) getOnce(r *resolution, name string) (any, error) {
+	c.mu.Lock()
+	owner, beanInfo, err := c.find(name)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, withPath(err, r.path)
+	}
+	name = beanInfo.Name
+
+	switch beanInfo.Scope {
+	case ScopePrototype:
+		c.mu.Unlock()
+		if slices.Contains(r.path, name) {
+			return nil, newError(ErrCircular, name, r.path)
+		}
+		return c.create(r, beanInfo)
+	case ScopeScoped:
+		if r.singleton != "" {
+			c.mu.Unlock()
+			e := newError(ErrScope, name, r.path)
+			e.msg = fmt.Sprintf("singleton %s cannot depend on scoped bean %s", r.singleton, name)
+			return nil, e
+		}
+		if c.parent == nil {
+			c.mu.Unlock()
+			e := newError(ErrScope, name, r.path)
+			e.msg = "scoped bean must be resolved from a scope: " + name
+			return nil, e
+		}
+		return c.getShared(r, beanInfo)
+	default:
+		return owner.getShared(r, beanInfo)
+	}
+}
+
+// getShared returns the bean cached in c, creating it if needed. Must be
+// called with c.mu held; it is released before returning.
+func (c *Container) getShared(r *resolution, beanInfo BeanInfo) (any, error) {
+	name := beanInfo.Name
 	if bean, ok := c.beans[name]; ok {
+		c.mu.Unlock()
 		return bean, nil
 	}
-	beanInfo, ok := c.infos[name]
-	if !ok {
-		return nil, errors.New("bean not found: " + name)
-	}
 
 	// check circular dependency
-	if _, ok := c.creating[name]; ok {
-		return nil, errors.New("circular dependency: " + name)
+	if slices.Contains(r.path, name) {
+		c.mu.Unlock()
+		return nil, newError(ErrCircular, name, r.path)
+	}
+
+	if cl, ok := c.calls[name]; ok {
+		// waiting on a chain that is itself waiting on us would never return
+		if waitsOn(cl, r) {
+			c.mu.Unlock()
+			return nil, newError(ErrCircular, name, r.path)
+		}
+		r.waiting = cl
+		c.mu.Unlock()
+
+		var err error
+		select {
+		case <-cl.done:
+		case <-r.ctx.Done():
+			err = fmt.Errorf("wait for %s: %w", name, r.ctx.Err())
+		}
+
+		c.mu.Lock()
+		r.waiting = nil
+		c.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return cl.bean, cl.err
 	}
-	c.creating[name] = struct{}{}
-	defer delete(c.creating, name)
 
+	cl := &call{done: make(chan struct{}), owner: r, epoch: c.epoch, gen: c.gens[name]}
+	c.calls[name] = cl
+	c.mu.Unlock()
+
+	// a panicking construction must not leave its call in flight, or every
+	// later Get of the bean would wait forever
+	returned := false
+	defer func() {
+		if returned {
+			return
+		}
+		v := recover()
+		c.mu.Lock()
+		delete(c.calls, name)
+		c.mu.Unlock()
+		cl.err = &Error{Kind: ErrConstruct, Name: name, Path: append(slices.Clone(r.path), name), Err: fmt.Errorf("panic: %v", v)}
+		close(cl.done)
+		panic(v)
+	}()
+	cl.bean, cl.err = c.create(r, beanInfo)
+	returned = true
+
+	c.mu.Lock()
+	notify := func() {}
+	if c.epoch != cl.epoch {
+		// the container was closed meanwhile, close the late bean
+		if cl.err == nil {
+			late := cl.bean
+			notify = func() { closeBean(context.Background(), late) }
+		}
+		cl.bean, cl.err = nil, &Error{Kind: ErrConstruct, Name: name, Path: append(slices.Clone(r.path), name), Err: ErrClosed}
+	} else if c.gens[name] != cl.gen {
+		// the bean was invalidated meanwhile, close the late bean and retry
+		if cl.err == nil {
+			late := cl.bean
+			notify = func() { closeBean(context.Background(), late) }
+		}
+		cl.bean, cl.err = nil, errStale
+	} else if cl.err == nil {
+		c.beans[name] = cl.bean
+		c.order = append(c.order, name)
+		c.track()
+		if old, ok := c.replaced[name]; ok {
+			delete(c.replaced, name)
+			notify = c.notifier(name, old, cl.bean)
+		}
+	}
+	delete(c.calls, name)
+	c.mu.Unlock()
+	close(cl.done)
+	notify()
+
+	return cl.bean, cl.err
+}
+
+func (c *Container) create(r *resolution, beanInfo BeanInfo) (any, error) {
+	r.path = append(r.path, beanInfo.Name)
+	defer func() { r.path = r.path[:len(r.path)-1] }()
+	if beanInfo.Scope == ScopeSingleton && r.singleton == "" {
+		r.singleton = beanInfo.Name
+		defer func() { r.singleton = "" }()
+	}
+
+	start := time.Now()
 	depends := make(map[string]any)
-	for _, dep := range beanInfo.Dependencies {
-		depBean, err := c.Get(dep)
+	for _, dep := range slices.Concat(beanInfo.Dependencies, beanInfo.OptionalDependencies) {
+		c.mu.Lock()
+		name, ok := c.dependency(beanInfo, dep)
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		depBean, err := c.get(r, name)
 		if err != nil {
 			return nil, err
 		}
 		depends[dep] = depBean
 	}
-	bean, err := beanInfo.Constructor(depends, beanInfo.Params)
+	for _, group := range beanInfo.GroupDependencies {
+		beans, err := c.getGroup(r, group)
+		if err != nil {
+			return nil, err
+		}
+		depends[group] = beans
+	}
+	resolved := time.Now()
+	var building atomic.Bool
+	building.Store(true)
+	defer building.Store(false)
+	for _, dep := range beanInfo.LazyDependencies {
+		depends[dep] = c.provider(r, dep, &building)
+	}
+	hooks := c.hooks(beanInfo.Name)
+	for _, hook := range hooks.before {
+		hook(beanInfo.Name, depends)
+	}
+	bean, err := beanInfo.Retry.retry(r.ctx, func() (any, error) {
+		return construct(r.ctx, beanInfo, depends)
+	})
+	if err == nil && beanInfo.InjectFields {
+		err = c.inject(r, bean)
+	}
+	for _, decorator := range hooks.decorators {
+		if err != nil {
+			break
+		}
+		bean, err = decorator(bean)
+	}
+	for _, hook := range hooks.after {
+		hook(beanInfo.Name, depends, bean, err)
+	}
+
+	end := time.Now()
+	t := Trace{
+		Name:     beanInfo.Name,
+		Trigger:  r.trigger,
+		Start:    start,
+		End:      end,
+		Duration: end.Sub(start),
+		Self:     end.Sub(resolved),
+		Err:      err,
+	}
+	c.mu.Lock()
+	if err != nil {
+		c.failed[beanInfo.Name] = err
+	} else {
+		delete(c.failed, beanInfo.Name)
+	}
+	for _, dep := range c.dependencies(beanInfo) {
+		if depInfo, err := c.lookup(dep); err == nil {
+			t.Dependencies = append(t.Dependencies, depInfo.Name)
+		}
+	}
+	c.mu.Unlock()
+	c.trace(t)
+
 	if err != nil {
-		return nil, fmt.Errorf("create %s: %w", beanInfo.Name, err)
+		return nil, &Error{Kind: ErrConstruct, Name: beanInfo.Name, Path: slices.Clone(r.path), Err: err}
 	}
-	c.beans[name] = bean
 	return bean, nil
 }
 
+// construct calls the constructor of beanInfo, giving up when ctx is done or
+// the bean's Timeout expires. A bean returned after giving up is closed. A
+// panic of the constructor is raised again on the calling goroutine, unless
+// construct has already given up.
+func construct(ctx context.Context, beanInfo BeanInfo, depends map[string]any) (any, error) {
+	if beanInfo.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, beanInfo.Timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ctx.Done() == nil {
+		return callConstructor(ctx, beanInfo, depends)
+	}
+
+	type result struct {
+		bean     any
+		err      error
+		panicked bool
+		panic    any
+	}
+	done := make(chan result, 1)
+	go func() {
+		returned := false
+		defer func() {
+			if !returned {
+				done <- result{panicked: true, panic: recover()}
+			}
+		}()
+		bean, err := callConstructor(ctx, beanInfo, depends)
+		returned = true
+		done <- result{bean: bean, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.panicked {
+			panic(res.panic)
+		}
+		return res.bean, res.err
+	case <-ctx.Done():
+		go func() {
+			if res := <-done; !res.panicked && res.err == nil {
+				closeBean(context.Background(), res.bean)
+			}
+		}()
+		if beanInfo.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s: %w", beanInfo.Timeout, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func callConstructor(ctx context.Context, beanInfo BeanInfo, depends map[string]any) (any, error) {
+	if beanInfo.ContextConstructor != nil {
+		return beanInfo.ContextConstructor(ctx, depends, beanInfo.Params)
+	}
+	return beanInfo.Constructor(depends, beanInfo.Params)
+}
+
+// track records c in the children of its parents, so that invalidations of
+// their beans reach the beans cached in c. Must be called with c.mu held.
+func (c *Container) track() {
+	for x := c; x.parent != nil; x = x.parent {
+		if x.parent.children == nil {
+			x.parent.children = make(map[*Container]struct{})
+		}
+		x.parent.children[x] = struct{}{}
+	}
+}
+
+// waitsOn reports whether waiting on cl would block on r, i.e. whether the
+// chain owning cl is (transitively) waiting for r or for a chain r blocks.
+// Must be called with c.mu held.
+func waitsOn(cl *call, r *resolution) bool {
+	for cl != nil {
+		for x := r; x != nil; x = x.parent {
+			if cl.owner == x {
+				return true
+			}
+		}
+		cl = cl.owner.waiting
+	}
+	return false
+}
+
 func Get[T any](container *Container, name string) (T, error) {
 	c, err := container.Get(name)
 	if err != nil {
@@ -83,7 +548,7 @@ func Get[T any](container *Container, name string) (T, error) {
 
 	if d, ok := c.(T); !ok {
 		var t T
-		return t, errors.New("type mismatch")
+		return t, mismatch(name, reflect.TypeFor[T](), c)
 	} else {
 		return d, nil
 	}
@@ -1,7 +1,10 @@
 package container
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -232,3 +235,120 @@ func (t *containerTestSuit) TestGetWithCast() {
 	t.Assertions.Error(err, "Get() should return error")
 	t.Assertions.Nil(b, "Get() should return nil")
 }
+
+func (t *containerTestSuit) TestGetConcurrent() {
+	c := New()
+	var created atomic.Int32
+	err := c.Register(BeanInfo{
+		Name: "bean1",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			created.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return &bean1{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+	err = c.Register(BeanInfo{
+		Name:         "bean2",
+		Dependencies: []string{"bean1"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean2{
+				bean1: depends["bean1"].(*bean1),
+			}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	const n = 16
+	beans := make([]any, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := "bean1"
+			if i%2 == 0 {
+				name = "bean2"
+			}
+			beans[i], errs[i] = c.Get(name)
+		}()
+	}
+	wg.Wait()
+
+	t.Assertions.Equal(int32(1), created.Load(), "bean1 should be created once")
+	for i := 0; i < n; i++ {
+		t.Assertions.NoError(errs[i], "Get() should not return error")
+		if i%2 == 0 {
+			t.Assertions.Same(beans[0], beans[i], "Get() should return the same bean2")
+		} else {
+			t.Assertions.Same(beans[1], beans[i], "Get() should return the same bean1")
+		}
+	}
+}
+
+func (t *containerTestSuit) TestGetConcurrentCircularDependencies() {
+	c := New()
+	start := make(chan struct{})
+	err := c.Register(BeanInfo{
+		Name:         "bean4",
+		Dependencies: []string{"bean5"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean4{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+	err = c.Register(BeanInfo{
+		Name:         "bean5",
+		Dependencies: []string{"bean4"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean5{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, name := range []string{"bean4", "bean5"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = c.Get(name)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	t.Assertions.Error(errs[0], "Get() should return error")
+	t.Assertions.Error(errs[1], "Get() should return error")
+}
+
+func (t *containerTestSuit) TestGetPanic() {
+	c := New()
+	var calls atomic.Int32
+	err := c.Register(BeanInfo{
+		Name: "bean1",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			if calls.Add(1) == 1 {
+				panic("boom")
+			}
+			return &bean1{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	t.Assertions.PanicsWithValue("boom", func() { _, _ = c.Get("bean1") }, "Get() should panic")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Get("bean1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Assertions.NoError(err, "Get() should not return error")
+	case <-time.After(time.Second):
+		t.Fail("Get() should not block after a panic")
+	}
+}
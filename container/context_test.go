@@ -0,0 +1,150 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type contextTestSuit struct {
+	suite.Suite
+}
+
+func TestContext(t *testing.T) {
+	suite.Run(t, new(contextTestSuit))
+}
+
+type ctxKey struct{}
+
+func (t *contextTestSuit) TestContextReachesChain() {
+	c := New()
+	var seen []any
+	constructor := func(ctx context.Context, depends map[string]any, params map[string]any) (interface{}, error) {
+		seen = append(seen, ctx.Value(ctxKey{}))
+		return &bean1{}, nil
+	}
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "bean1", ContextConstructor: constructor}))
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "bean2", Dependencies: []string{"bean1"}, ContextConstructor: constructor}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	_, err := c.GetContext(ctx, "bean2")
+	t.Assertions.NoError(err, "GetContext() should not return error")
+	t.Assertions.Equal([]any{"value", "value"}, seen, "context should reach every constructor")
+}
+
+func (t *contextTestSuit) TestTimeout() {
+	c := New()
+	closed := make(chan string, 1)
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:    "slow",
+		Timeout: 10 * time.Millisecond,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			time.Sleep(50 * time.Millisecond)
+			return &closeRecorder{name: "slow", closed: new([]string)}, nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "server",
+		Dependencies: []string{"slow"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			closed <- "server should not be created"
+			return nil, nil
+		},
+	}))
+
+	start := time.Now()
+	_, err := c.Get("server")
+	t.Assertions.Less(time.Since(start), 40*time.Millisecond, "Get() should not wait for the constructor")
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded)
+	t.Assertions.ErrorContains(err, "create slow: timed out after 10ms")
+	t.Assertions.Empty(closed)
+}
+
+func (t *contextTestSuit) TestCanceled() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "slow",
+		ContextConstructor: func(ctx context.Context, depends map[string]any, params map[string]any) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.GetContext(ctx, "slow")
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded)
+	t.Assertions.ErrorContains(err, "create slow")
+	t.Assertions.NotContains(err.Error(), "timed out after", "only bean timeouts should be reported as such")
+}
+
+func (t *contextTestSuit) TestPanic() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:    "bean1",
+		Timeout: time.Second,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			panic("boom")
+		},
+	}))
+
+	t.Assertions.PanicsWithValue("boom", func() { _, _ = c.Get("bean1") }, "Get() should panic on the calling goroutine")
+}
+
+func (t *contextTestSuit) TestWaitCanceled() {
+	c := New()
+	release := make(chan struct{})
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "slow",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			<-release
+			return &bean1{}, nil
+		},
+	}))
+	defer close(release)
+
+	go c.Get("slow")
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.GetContext(ctx, "slow")
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded)
+	t.Assertions.ErrorContains(err, "wait for slow")
+}
+
+func (t *contextTestSuit) TestRegisterBothConstructors() {
+	c := New()
+	err := c.Register(BeanInfo{
+		Name: "bean1",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return nil, nil
+		},
+		ContextConstructor: func(ctx context.Context, depends map[string]any, params map[string]any) (interface{}, error) {
+			return nil, nil
+		},
+	})
+	t.Assertions.Error(err, "Register() should return error")
+}
+
+func (t *contextTestSuit) TestProvideContext() {
+	c := New()
+	errNoValue := errors.New("no value")
+	t.Assertions.NoError(c.Provide(newDB))
+	t.Assertions.NoError(c.Provide(func(ctx context.Context, d *db) (*cache, error) {
+		if ctx.Value(ctxKey{}) == nil {
+			return nil, errNoValue
+		}
+		return &cache{db: d}, nil
+	}))
+
+	name := typeName(reflect.TypeOf(&cache{}))
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	cc, err := c.GetContext(ctx, name)
+	t.Assertions.NoError(err, "GetContext() should not return error")
+	t.Assertions.NotNil(cc.(*cache).db, "db should be injected")
+}
@@ -0,0 +1,105 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var (
+	// ErrNotFound is matched by errors for names no definition satisfies.
+	ErrNotFound = errors.New("bean not found")
+	// ErrCircular is matched by errors for beans depending on themselves.
+	ErrCircular = errors.New("circular dependency")
+	// ErrTypeMismatch is matched by errors for beans of an unexpected type.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrConstruct is matched by errors returned while constructing a bean,
+	// by its constructor, decorators or field injection.
+	ErrConstruct = errors.New("construct failed")
+	// ErrAmbiguous is matched by errors for names or types that several
+	// definitions satisfy.
+	ErrAmbiguous = errors.New("ambiguous bean")
+	// ErrScope is matched by errors for beans resolved out of their scope,
+	// such as a singleton depending on a scoped bean.
+	ErrScope = errors.New("scope violation")
+	// ErrClosed is the cause of the ErrConstruct of beans whose container
+	// was closed while they were being constructed.
+	ErrClosed = errors.New("container closed")
+)
+
+// Error is the error returned when a bean cannot be resolved. It matches its
+// Kind and wraps Err with errors.Is and errors.As.
+type Error struct {
+	// Kind is one of ErrNotFound, ErrCircular, ErrTypeMismatch,
+	// ErrConstruct, ErrAmbiguous and ErrScope.
+	Kind error
+	Name string
+	// Path is the chain of beans being resolved, outermost first, ending
+	// with Name.
+	Path []string
+	// Expected and Actual are the types of an ErrTypeMismatch.
+	Expected reflect.Type
+	Actual   reflect.Type
+	// Err is the cause of an ErrConstruct.
+	Err error
+	// Candidates are the beans matching an ErrAmbiguous, if known.
+	Candidates []string
+
+	// msg replaces the default message.
+	msg string
+}
+
+func (e *Error) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	switch e.Kind {
+	case ErrNotFound:
+		return "bean not found: " + e.Name
+	case ErrCircular:
+		return "circular dependency: " + e.Name
+	case ErrTypeMismatch:
+		msg := fmt.Sprintf("type mismatch: expected %v, got %v", e.Expected, e.Actual)
+		if e.Name != "" {
+			msg = "bean " + e.Name + ": " + msg
+		}
+		return msg
+	case ErrConstruct:
+		return fmt.Sprintf("create %s: %v", e.Name, e.Err)
+	default:
+		return fmt.Sprintf("%v: %s", e.Kind, e.Name)
+	}
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newError returns an Error of kind for name, reached through the beans of
+// path.
+func newError(kind error, name string, path []string) *Error {
+	return &Error{Kind: kind, Name: name, Path: append(slices.Clone(path), name)}
+}
+
+// mismatch returns an ErrTypeMismatch of bean against the expected type.
+func mismatch(name string, expected reflect.Type, bean any) *Error {
+	e := &Error{Kind: ErrTypeMismatch, Name: name, Expected: expected, Actual: reflect.TypeOf(bean)}
+	if name != "" {
+		e.Path = []string{name}
+	}
+	return e
+}
+
+// withPath sets the path of the Error in err that has none, for errors
+// created without knowing the resolution chain.
+func withPath(err error, path []string) error {
+	var e *Error
+	if errors.As(err, &e) && e.Path == nil {
+		e.Path = append(slices.Clone(path), e.Name)
+	}
+	return err
+}
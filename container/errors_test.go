@@ -0,0 +1,165 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorsTestSuit struct {
+	suite.Suite
+}
+
+func TestErrors(t *testing.T) {
+	suite.Run(t, new(errorsTestSuit))
+}
+
+func (t *errorsTestSuit) register(c *Container, name string, err error, deps ...string) {
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: deps,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return name, err
+		},
+	}))
+}
+
+func (t *errorsTestSuit) TestNotFound() {
+	c := New()
+	t.register(c, "service", nil, "repo")
+	t.register(c, "repo", nil, "config")
+
+	_, err := c.Get("service")
+	t.Assertions.EqualError(err, "bean not found: config")
+	t.Assertions.ErrorIs(err, ErrNotFound)
+	t.Assertions.NotErrorIs(err, ErrConstruct)
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal("config", e.Name)
+	t.Assertions.Equal([]string{"service", "repo", "config"}, e.Path)
+
+	t.Assertions.ErrorIs(c.Validate(), ErrNotFound)
+	_, err = Resolve[error](c)
+	t.Assertions.ErrorIs(err, ErrNotFound)
+}
+
+func (t *errorsTestSuit) TestCircular() {
+	c := New()
+	t.register(c, "a", nil, "b")
+	t.register(c, "b", nil, "a")
+
+	_, err := c.Get("a")
+	t.Assertions.EqualError(err, "circular dependency: a")
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal(ErrCircular, e.Kind)
+	t.Assertions.Equal([]string{"a", "b", "a"}, e.Path)
+
+	err = c.Validate()
+	t.Assertions.EqualError(err, "circular dependency: a -> b -> a")
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal([]string{"a", "b", "a"}, e.Path)
+}
+
+func (t *errorsTestSuit) TestTypeMismatch() {
+	c := New()
+	t.register(c, "name", nil)
+
+	_, err := Get[int](c, "name")
+	t.Assertions.ErrorIs(err, ErrTypeMismatch)
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal("name", e.Name)
+	t.Assertions.Equal(reflect.TypeFor[int](), e.Expected)
+	t.Assertions.Equal(reflect.TypeFor[string](), e.Actual)
+}
+
+func (t *errorsTestSuit) TestConstruct() {
+	cause := errors.New("connection refused")
+	c := New()
+	t.register(c, "service", nil, "db")
+	t.register(c, "db", cause)
+
+	_, err := c.Get("service")
+	t.Assertions.EqualError(err, "create db: connection refused")
+	t.Assertions.ErrorIs(err, ErrConstruct)
+	t.Assertions.ErrorIs(err, cause, "the constructor error should be wrapped")
+	t.Assertions.NotErrorIs(err, ErrNotFound)
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal("db", e.Name)
+	t.Assertions.Equal([]string{"service", "db"}, e.Path)
+	t.Assertions.Equal(cause, e.Err)
+}
+
+func (t *errorsTestSuit) TestConstructNotFound() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "service",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return nil, c.Inject(&struct {
+				DB string `inject:"db"`
+			}{})
+		},
+	}))
+
+	_, err := c.Get("service")
+	t.Assertions.ErrorIs(err, ErrConstruct)
+	t.Assertions.ErrorIs(err, ErrNotFound, "errors of nested resolutions should be kept")
+}
+
+func (t *errorsTestSuit) TestAmbiguous() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }, WithName("a")))
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }, WithName("b")))
+	t.register(c, "service", nil, typeName(reflect.TypeFor[memStore]()))
+
+	_, err := c.Get("service")
+	t.Assertions.ErrorIs(err, ErrAmbiguous)
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal([]string{"a", "b"}, e.Candidates)
+	t.Assertions.Equal([]string{"service", typeName(reflect.TypeFor[memStore]())}, e.Path)
+
+	_, err = Resolve[store](c)
+	t.Assertions.ErrorIs(err, ErrAmbiguous)
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal(reflect.TypeFor[store](), e.Expected)
+	t.Assertions.Equal([]string{"a", "b"}, e.Candidates)
+
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "conditional", Conditions: []Condition{OnProfile("a")}, Constructor: t.constructor}))
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "conditional", Conditions: []Condition{OnProfile("b")}, Constructor: t.constructor}))
+	c.SetProfiles("a", "b")
+	_, err = c.Get("conditional")
+	t.Assertions.EqualError(err, "2 definitions of bean conditional match, active profiles: [a b]")
+	t.Assertions.ErrorIs(err, ErrAmbiguous)
+}
+
+func (t *errorsTestSuit) constructor(depends map[string]any, params map[string]any) (interface{}, error) {
+	return "bean", nil
+}
+
+func (t *errorsTestSuit) TestScope() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "session", Scope: ScopeScoped, Constructor: t.constructor}))
+	t.register(c, "service", nil, "session")
+
+	_, err := c.Get("session")
+	t.Assertions.EqualError(err, "scoped bean must be resolved from a scope: session")
+	t.Assertions.ErrorIs(err, ErrScope)
+
+	_, err = c.NewScope().Get("service")
+	t.Assertions.EqualError(err, "singleton service cannot depend on scoped bean session")
+	var e *Error
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal(ErrScope, e.Kind)
+	t.Assertions.Equal("session", e.Name)
+	t.Assertions.Equal([]string{"service", "session"}, e.Path)
+
+	err = c.Validate()
+	t.Assertions.ErrorIs(err, ErrScope)
+	t.Assertions.True(errors.As(err, &e))
+	t.Assertions.Equal([]string{"service", "session"}, e.Path)
+}
@@ -0,0 +1,162 @@
+package container
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type NodeState int
+
+const (
+	NodeNotCreated NodeState = iota
+	NodeCreated
+	NodeFailed
+	// NodeMissing marks a dependency that no registered bean satisfies.
+	NodeMissing
+)
+
+func (s NodeState) String() string {
+	switch s {
+	case NodeNotCreated:
+		return "not created"
+	case NodeCreated:
+		return "created"
+	case NodeFailed:
+		return "failed"
+	case NodeMissing:
+		return "missing"
+	default:
+		return fmt.Sprintf("NodeState(%d)", int(s))
+	}
+}
+
+type GraphNode struct {
+	Name  string
+	Scope Scope
+	State NodeState
+	// Err is the constructor error of a failed node.
+	Err error
+}
+
+// GraphEdge is a dependency of From on To.
+type GraphEdge struct {
+	From string
+	To   string
+	// Lazy marks a lazy dependency, see BeanInfo.LazyDependencies.
+	Lazy bool
+}
+
+// Graph is a snapshot of the beans visible from a container and their
+// dependencies, with nodes and edges sorted by name.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+func (c *Container) Graph() Graph {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var g Graph
+	missing := make(map[string]struct{})
+	for _, name := range c.names() {
+		owner, beanInfo, err := c.find(name)
+		if err != nil {
+			g.Nodes = append(g.Nodes, GraphNode{Name: name, State: NodeMissing, Err: err})
+			continue
+		}
+		holder := c.holder(owner, beanInfo)
+
+		node := GraphNode{Name: name, Scope: beanInfo.Scope}
+		if _, ok := holder.beans[name]; ok {
+			node.State = NodeCreated
+		} else if err, ok := holder.failed[name]; ok {
+			node.State = NodeFailed
+			node.Err = err
+		}
+		g.Nodes = append(g.Nodes, node)
+
+		deps := holder.dependencies(beanInfo)
+		for i, dep := range append(deps, beanInfo.LazyDependencies...) {
+			if depInfo, err := holder.lookup(dep); err == nil {
+				dep = depInfo.Name
+			} else {
+				missing[dep] = struct{}{}
+			}
+			g.Edges = append(g.Edges, GraphEdge{From: name, To: dep, Lazy: i >= len(deps)})
+		}
+	}
+	for _, n := range g.Nodes {
+		delete(missing, n.Name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(missing)) {
+		g.Nodes = append(g.Nodes, GraphNode{Name: name, State: NodeMissing})
+	}
+	slices.SortFunc(g.Edges, func(a, b GraphEdge) int {
+		if n := strings.Compare(a.From, b.From); n != 0 {
+			return n
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return g
+}
+
+var dotStyles = map[NodeState]string{
+	NodeNotCreated: `style=filled fillcolor="white"`,
+	NodeCreated:    `style=filled fillcolor="palegreen"`,
+	NodeFailed:     `style=filled fillcolor="lightcoral"`,
+	NodeMissing:    `style=dashed`,
+}
+
+// DOT renders the graph in the Graphviz DOT language.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph container {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "  %q [label=%q %s];\n", n.Name, n.Name+"\n"+n.State.String(), dotStyles[n.State])
+	}
+	for _, e := range g.Edges {
+		if e.Lazy {
+			fmt.Fprintf(&sb, "  %q -> %q [style=dashed];\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", e.From, e.To)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+var mermaidClasses = map[NodeState]string{
+	NodeNotCreated: "notCreated",
+	NodeCreated:    "created",
+	NodeFailed:     "failed",
+	NodeMissing:    "missing",
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.Name] = id
+		label := strings.ReplaceAll(n.Name, `"`, "#quot;")
+		fmt.Fprintf(&sb, "  %s[\"%s\"]:::%s\n", id, label, mermaidClasses[n.State])
+	}
+	for _, e := range g.Edges {
+		if e.Lazy {
+			fmt.Fprintf(&sb, "  %s -.-> %s\n", ids[e.From], ids[e.To])
+		} else {
+			fmt.Fprintf(&sb, "  %s --> %s\n", ids[e.From], ids[e.To])
+		}
+	}
+	sb.WriteString("  classDef notCreated fill:#fff,stroke:#999\n")
+	sb.WriteString("  classDef created fill:#9f9,stroke:#393\n")
+	sb.WriteString("  classDef failed fill:#f99,stroke:#c33\n")
+	sb.WriteString("  classDef missing fill:#fff,stroke:#999,stroke-dasharray:5 5\n")
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type graphTestSuit struct {
+	suite.Suite
+}
+
+func TestGraph(t *testing.T) {
+	suite.Run(t, new(graphTestSuit))
+}
+
+func (t *graphTestSuit) newContainer() *Container {
+	c := New()
+	errFailed := errors.New("failed")
+	beans := []BeanInfo{
+		{Name: "db"},
+		{Name: "cache", Dependencies: []string{"db"}},
+		{Name: "broken", Dependencies: []string{"db"}},
+		{Name: "server", Dependencies: []string{"cache", "missing"}},
+	}
+	for _, b := range beans {
+		b.Constructor = func(depends map[string]any, params map[string]any) (interface{}, error) {
+			if b.Name == "broken" {
+				return nil, errFailed
+			}
+			return b.Name, nil
+		}
+		t.Assertions.NoError(c.Register(b), "Register() should not return error")
+	}
+	_, err := c.Get("cache")
+	t.Assertions.NoError(err, "Get() should not return error")
+	_, err = c.Get("broken")
+	t.Assertions.ErrorIs(err, errFailed, "Get() should return error")
+	return c
+}
+
+func (t *graphTestSuit) TestGraph() {
+	g := t.newContainer().Graph()
+
+	t.Assertions.Len(g.Nodes, 5)
+	states := make(map[string]NodeState)
+	for _, n := range g.Nodes {
+		states[n.Name] = n.State
+	}
+	t.Assertions.Equal(map[string]NodeState{
+		"broken":  NodeFailed,
+		"cache":   NodeCreated,
+		"db":      NodeCreated,
+		"missing": NodeMissing,
+		"server":  NodeNotCreated,
+	}, states)
+	t.Assertions.Equal([]GraphEdge{
+		{From: "broken", To: "db"},
+		{From: "cache", To: "db"},
+		{From: "server", To: "cache"},
+		{From: "server", To: "missing"},
+	}, g.Edges)
+}
+
+func (t *graphTestSuit) TestDOT() {
+	dot := t.newContainer().Graph().DOT()
+
+	t.Assertions.Contains(dot, "digraph container {\n")
+	t.Assertions.Contains(dot, `"cache" [label="cache\ncreated" style=filled fillcolor="palegreen"];`)
+	t.Assertions.Contains(dot, `"broken" [label="broken\nfailed" style=filled fillcolor="lightcoral"];`)
+	t.Assertions.Contains(dot, `"missing" [label="missing\nmissing" style=dashed];`)
+	t.Assertions.Contains(dot, `"server" -> "cache";`)
+}
+
+func (t *graphTestSuit) TestMermaid() {
+	mermaid := t.newContainer().Graph().Mermaid()
+
+	t.Assertions.Contains(mermaid, "flowchart TD\n")
+	t.Assertions.Contains(mermaid, `n0["broken"]:::failed`)
+	t.Assertions.Contains(mermaid, `n1["cache"]:::created`)
+	t.Assertions.Contains(mermaid, `n3["server"]:::notCreated`)
+	t.Assertions.Contains(mermaid, `n4["missing"]:::missing`)
+	t.Assertions.Contains(mermaid, "n3 --> n1")
+}
+
+func (t *graphTestSuit) TestUnmatched() {
+	c := New()
+	beans := []BeanInfo{
+		{Name: "db", Conditions: []Condition{OnProfile("prod")}},
+		{Name: "cache", Dependencies: []string{"db"}},
+	}
+	for _, b := range beans {
+		b.Constructor = func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return b.Name, nil
+		}
+		t.Assertions.NoError(c.Register(b), "Register() should not return error")
+	}
+	g := c.Graph()
+
+	t.Assertions.Len(g.Nodes, 2, "unmatched beans should have a single node")
+	for _, n := range g.Nodes {
+		if n.Name == "db" {
+			t.Assertions.Equal(NodeMissing, n.State)
+			t.Assertions.ErrorIs(n.Err, ErrNotFound)
+		}
+	}
+	t.Assertions.Equal(1, strings.Count(g.Mermaid(), `["db"]`), "Mermaid() should render db once")
+}
@@ -0,0 +1,70 @@
+package container
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// GetGroup returns every bean of group visible from c, created if needed,
+// ordered by Priority and then by name.
+func (c *Container) GetGroup(group string) ([]any, error) {
+	return c.getGroup(&resolution{ctx: context.Background(), trigger: group}, group)
+}
+
+// GetGroup returns the beans of group, which must all be of type T, see
+// Container.GetGroup.
+func GetGroup[T any](container *Container, group string) ([]T, error) {
+	beans, err := container.GetGroup(group)
+	if err != nil {
+		return nil, err
+	}
+	ts := make([]T, len(beans))
+	for i, bean := range beans {
+		t, ok := bean.(T)
+		if !ok {
+			return nil, fmt.Errorf("group %s: %w", group, mismatch("", reflect.TypeFor[T](), bean))
+		}
+		ts[i] = t
+	}
+	return ts, nil
+}
+
+func (c *Container) getGroup(r *resolution, group string) ([]any, error) {
+	c.mu.Lock()
+	names := c.group(group)
+	c.mu.Unlock()
+
+	beans := make([]any, len(names))
+	for i, name := range names {
+		bean, err := c.get(r, name)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", group, err)
+		}
+		beans[i] = bean
+	}
+	return beans, nil
+}
+
+// group returns the names of the beans of group visible from c, in group
+// order. Must be called with c.mu held.
+func (c *Container) group(group string) []string {
+	var members []BeanInfo
+	for _, name := range c.names() {
+		beanInfo, err := c.lookup(name)
+		if err == nil && slices.Contains(beanInfo.Groups, group) {
+			members = append(members, beanInfo)
+		}
+	}
+	slices.SortStableFunc(members, func(a, b BeanInfo) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	names := make([]string, len(members))
+	for i, beanInfo := range members {
+		names[i] = beanInfo.Name
+	}
+	return names
+}
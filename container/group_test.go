@@ -0,0 +1,118 @@
+package container
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type groupTestSuit struct {
+	suite.Suite
+}
+
+func TestGroup(t *testing.T) {
+	suite.Run(t, new(groupTestSuit))
+}
+
+type route string
+
+func (t *groupTestSuit) register(c *Container, name string, priority int, groups ...string) {
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:     name,
+		Groups:   groups,
+		Priority: priority,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return route(name), nil
+		},
+	}))
+}
+
+func (t *groupTestSuit) TestGetGroup() {
+	c := New()
+	t.register(c, "users", 0, "routes")
+	t.register(c, "health", -1, "routes", "probes")
+	t.register(c, "admin", 0, "routes")
+	t.register(c, "metrics", 0, "probes")
+
+	routes, err := GetGroup[route](c, "routes")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Equal([]route{"health", "admin", "users"}, routes, "beans should be ordered by priority, then name")
+
+	probes, err := c.GetGroup("probes")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Equal([]any{route("health"), route("metrics")}, probes)
+
+	empty, err := c.GetGroup("none")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Empty(empty)
+
+	_, err = GetGroup[string](c, "routes")
+	t.Assertions.EqualError(err, "group routes: type mismatch: expected string, got container.route")
+}
+
+func (t *groupTestSuit) TestGroupDependencies() {
+	c := New()
+	t.register(c, "users", 0, "routes")
+	t.register(c, "admin", 0, "routes")
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:              "server",
+		GroupDependencies: []string{"routes"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			var paths []string
+			for _, r := range depends["routes"].([]any) {
+				paths = append(paths, string(r.(route)))
+			}
+			return strings.Join(paths, ","), nil
+		},
+	}))
+
+	t.Assertions.NoError(c.Validate(), "Validate() should not return error")
+	t.Assertions.Contains(c.Graph().Edges, GraphEdge{From: "server", To: "users"})
+
+	server, err := Get[string](c, "server")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("admin,users", server)
+}
+
+func (t *groupTestSuit) TestCycle() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:              "registry",
+		Groups:            []string{"plugins"},
+		GroupDependencies: []string{"plugins"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return "registry", nil
+		},
+	}))
+	t.Assertions.ErrorContains(c.Validate(), "circular dependency: registry -> registry")
+	t.Assertions.ErrorContains(c.InitAll(context.Background(), 0), "circular dependency: registry")
+}
+
+func (t *groupTestSuit) TestChild() {
+	c := New()
+	t.register(c, "users", 0, "routes")
+	t.register(c, "admin", 0, "routes")
+	child := c.NewChild()
+	t.register(child, "debug", 0, "routes")
+	t.register(child, "admin", 1)
+
+	routes, err := GetGroup[route](child, "routes")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Equal([]route{"debug", "users"}, routes, "the child should add to and shadow the groups of its parent")
+
+	routes, err = GetGroup[route](c, "routes")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Equal([]route{"admin", "users"}, routes)
+}
+
+func (t *groupTestSuit) TestProvide() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() route { return "users" }, WithName("users"), WithGroups("routes"), WithPriority(2)))
+	t.Assertions.NoError(c.Provide(func() route { return "admin" }, WithName("admin"), WithGroups("routes"), WithPriority(1)))
+
+	routes, err := GetGroup[route](c, "routes")
+	t.Assertions.NoError(err, "GetGroup() should not return error")
+	t.Assertions.Equal([]route{"admin", "users"}, routes)
+}
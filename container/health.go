@@ -0,0 +1,136 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthChecker is implemented by beans that can report their health.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// DefaultHealthTimeout bounds the health check of beans without a
+// HealthTimeout.
+const DefaultHealthTimeout = 5 * time.Second
+
+type HealthStatus string
+
+const (
+	HealthUp HealthStatus = "up"
+	// HealthDegraded reports that only non-critical beans are down.
+	HealthDegraded HealthStatus = "degraded"
+	HealthDown     HealthStatus = "down"
+)
+
+// BeanHealth is the result of the health check of a bean.
+type BeanHealth struct {
+	Name     string        `json:"name"`
+	Status   HealthStatus  `json:"status"`
+	Critical bool          `json:"critical"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// HealthReport is the result of Container.Health, with beans sorted by name.
+type HealthReport struct {
+	Status HealthStatus `json:"status"`
+	Beans  []BeanHealth `json:"beans"`
+}
+
+// Health runs the health checks of the created beans of c and its parents
+// implementing HealthChecker, concurrently. Each check is bounded by the
+// bean's HealthTimeout, DefaultHealthTimeout if unset, and by ctx. Beans not
+// created yet are not checked.
+func (c *Container) Health(ctx context.Context) HealthReport {
+	type check struct {
+		name     string
+		checker  HealthChecker
+		critical bool
+		timeout  time.Duration
+	}
+	var checks []check
+	c.mu.Lock()
+	for _, name := range c.names() {
+		owner, beanInfo, err := c.find(name)
+		if err != nil {
+			continue
+		}
+		checker, ok := c.holder(owner, beanInfo).beans[name].(HealthChecker)
+		if !ok {
+			continue
+		}
+		timeout := beanInfo.HealthTimeout
+		if timeout <= 0 {
+			timeout = DefaultHealthTimeout
+		}
+		checks = append(checks, check{name, checker, !beanInfo.NonCritical, timeout})
+	}
+	c.mu.Unlock()
+
+	report := HealthReport{Status: HealthUp, Beans: make([]BeanHealth, len(checks))}
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := healthCheck(ctx, ch.checker, ch.timeout)
+			h := BeanHealth{Name: ch.name, Status: HealthUp, Critical: ch.critical, Duration: time.Since(start)}
+			if err != nil {
+				h.Status = HealthDown
+				h.Error = err.Error()
+			}
+			report.Beans[i] = h
+		}()
+	}
+	wg.Wait()
+
+	for _, h := range report.Beans {
+		if h.Status != HealthDown {
+			continue
+		}
+		if h.Critical {
+			report.Status = HealthDown
+		} else if report.Status == HealthUp {
+			report.Status = HealthDegraded
+		}
+	}
+	return report
+}
+
+// healthCheck runs the check of checker, giving up after timeout even if it
+// does not return.
+func healthCheck(ctx context.Context, checker HealthChecker, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- checker.HealthCheck(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("health check: %w", ctx.Err())
+	}
+}
+
+// HealthHandler serves the health report of c as JSON, with status 503 when
+// it is down. Degraded containers are served with status 200, so the handler
+// suits both readiness and liveness probes.
+func (c *Container) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Health(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status == HealthDown {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,132 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type healthTestSuit struct {
+	suite.Suite
+}
+
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(healthTestSuit))
+}
+
+type checker struct {
+	err   error
+	sleep time.Duration
+}
+
+func (c *checker) HealthCheck(ctx context.Context) error {
+	select {
+	case <-time.After(c.sleep):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *healthTestSuit) register(c *Container, beanInfo BeanInfo, bean any) {
+	beanInfo.Constructor = func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return bean, nil
+	}
+	t.Assertions.NoError(c.Register(beanInfo))
+}
+
+func (t *healthTestSuit) TestHealth() {
+	c := New()
+	t.register(c, BeanInfo{Name: "db"}, &checker{})
+	t.register(c, BeanInfo{Name: "cache", NonCritical: true}, &checker{err: errors.New("connection refused")})
+	t.register(c, BeanInfo{Name: "plain"}, "not a checker")
+	t.register(c, BeanInfo{Name: "idle"}, &checker{err: errors.New("never created")})
+
+	t.Assertions.Equal(HealthReport{Status: HealthUp, Beans: []BeanHealth{}}, c.Health(context.Background()), "beans not created should not be checked")
+
+	t.Assertions.NoError(c.InitAll(context.Background(), 0, "db", "cache", "plain"))
+	report := c.Health(context.Background())
+	t.Assertions.Equal(HealthDegraded, report.Status, "non-critical failures should degrade the container")
+	t.Assertions.Len(report.Beans, 2)
+	t.Assertions.Equal("cache", report.Beans[0].Name)
+	t.Assertions.Equal(HealthDown, report.Beans[0].Status)
+	t.Assertions.False(report.Beans[0].Critical)
+	t.Assertions.Equal("connection refused", report.Beans[0].Error)
+	t.Assertions.Equal("db", report.Beans[1].Name)
+	t.Assertions.Equal(HealthUp, report.Beans[1].Status)
+	t.Assertions.True(report.Beans[1].Critical)
+}
+
+func (t *healthTestSuit) TestTimeout() {
+	c := New()
+	t.register(c, BeanInfo{Name: "slow", HealthTimeout: 10 * time.Millisecond}, &checker{sleep: time.Second})
+	t.register(c, BeanInfo{Name: "stuck", HealthTimeout: 10 * time.Millisecond}, stuck{})
+	t.Assertions.NoError(c.InitAll(context.Background(), 0))
+
+	start := time.Now()
+	report := c.Health(context.Background())
+	t.Assertions.Less(time.Since(start), 500*time.Millisecond, "checks should run concurrently and time out")
+	t.Assertions.Equal(HealthDown, report.Status)
+	t.Assertions.Contains(report.Beans[0].Error, "context deadline exceeded")
+	t.Assertions.Equal("health check: context deadline exceeded", report.Beans[1].Error)
+}
+
+// stuck ignores the context of its health check.
+type stuck struct{}
+
+func (stuck) HealthCheck(context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func (t *healthTestSuit) TestChild() {
+	c := New()
+	t.register(c, BeanInfo{Name: "db"}, &checker{})
+	child := c.NewChild()
+	t.register(child, BeanInfo{Name: "session", Scope: ScopeScoped}, &checker{err: errors.New("expired")})
+	t.Assertions.NoError(child.InitAll(context.Background(), 0))
+
+	t.Assertions.Len(c.Health(context.Background()).Beans, 1, "the parent should not check beans of children")
+	report := child.Health(context.Background())
+	t.Assertions.Equal(HealthDown, report.Status)
+	t.Assertions.Len(report.Beans, 2, "the child should check beans of its parents")
+}
+
+func (t *healthTestSuit) TestHandler() {
+	c := New()
+	bean := &checker{}
+	t.register(c, BeanInfo{Name: "db"}, bean)
+	t.Assertions.NoError(c.InitAll(context.Background(), 0))
+
+	rec := httptest.NewRecorder()
+	c.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	t.Assertions.Equal(http.StatusOK, rec.Code)
+	t.Assertions.Equal("application/json", rec.Header().Get("Content-Type"))
+	var report HealthReport
+	t.Assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &report))
+	t.Assertions.Equal(HealthUp, report.Status)
+	t.Assertions.Equal("db", report.Beans[0].Name)
+
+	bean.err = errors.New("down")
+	rec = httptest.NewRecorder()
+	c.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	t.Assertions.Equal(http.StatusServiceUnavailable, rec.Code)
+	t.Assertions.Contains(rec.Body.String(), `"error":"down"`)
+}
+
+func (t *healthTestSuit) TestConfig() {
+	c := New()
+	t.Assertions.NoError(c.RegisterFactory("cache", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		return &checker{err: errors.New("down")}, nil
+	}))
+	t.Assertions.NoError(c.LoadConfig(strings.NewReader(`{"beans": [{"name": "cache", "factory": "cache", "non_critical": true}]}`), ConfigFormatJSON))
+	t.Assertions.NoError(c.InitAll(context.Background(), 0))
+	t.Assertions.Equal(HealthDegraded, c.Health(context.Background()).Status)
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Decorator wraps a bean once it is constructed, returning the bean to use
+// in its place.
+type Decorator func(bean any) (any, error)
+
+// BeforeCreateHook is called before a bean is constructed, with its
+// resolved dependencies.
+type BeforeCreateHook func(name string, depends map[string]any)
+
+// AfterCreateHook is called after a bean is constructed and decorated, with
+// the result of the construction.
+type AfterCreateHook func(name string, depends map[string]any, bean any, err error)
+
+// Decorate adds a decorator to the bean name. Decorators apply in the order
+// they are added, to every bean created afterwards by c or its children.
+func (c *Container) Decorate(name string, decorator Decorator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decorators == nil {
+		c.decorators = make(map[string][]Decorator)
+	}
+	c.decorators[name] = append(c.decorators[name], decorator)
+}
+
+// Decorate adds a decorator of beans of type T to the bean name, see
+// Container.Decorate.
+func Decorate[T any](container *Container, name string, decorator func(T) (T, error)) {
+	container.Decorate(name, func(bean any) (any, error) {
+		t, ok := bean.(T)
+		if !ok {
+			return nil, fmt.Errorf("decorate %s: %w", name, mismatch("", reflect.TypeFor[T](), bean))
+		}
+		return decorator(t)
+	})
+}
+
+// OnBeforeCreate adds a hook called before every bean created by c or its
+// children is constructed.
+func (c *Container) OnBeforeCreate(hook BeforeCreateHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.beforeHooks = append(c.beforeHooks, hook)
+}
+
+// OnAfterCreate adds a hook called after every bean created by c or its
+// children is constructed.
+func (c *Container) OnAfterCreate(hook AfterCreateHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.afterHooks = append(c.afterHooks, hook)
+}
+
+type beanHooks struct {
+	decorators []Decorator
+	before     []BeforeCreateHook
+	after      []AfterCreateHook
+}
+
+// hooks collects the decorators of name and the creation hooks of c and its
+// parents, outermost first.
+func (c *Container) hooks(name string) beanHooks {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var h beanHooks
+	for x := c; x != nil; x = x.parent {
+		h.decorators = append(slices.Clone(x.decorators[name]), h.decorators...)
+		h.before = append(slices.Clone(x.beforeHooks), h.before...)
+		h.after = append(slices.Clone(x.afterHooks), h.after...)
+	}
+	return h
+}
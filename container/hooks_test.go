@@ -0,0 +1,122 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type hooksTestSuit struct {
+	suite.Suite
+}
+
+func TestHooks(t *testing.T) {
+	suite.Run(t, new(hooksTestSuit))
+}
+
+type countingClient struct {
+	client
+	calls int
+}
+
+func (c *countingClient) Call() string {
+	c.calls++
+	return c.client.Call()
+}
+
+func (t *hooksTestSuit) newContainer() *Container {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "client",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return client(realClient{}), nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "api",
+		Dependencies: []string{"client"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &api{client: depends["client"].(client)}, nil
+		},
+	}))
+	return c
+}
+
+func (t *hooksTestSuit) TestDecorate() {
+	c := t.newContainer()
+	var order []string
+	Decorate(c, "client", func(cl client) (client, error) {
+		order = append(order, "first")
+		return &countingClient{client: cl}, nil
+	})
+	c.Decorate("client", func(bean any) (any, error) {
+		order = append(order, "second")
+		return bean, nil
+	})
+
+	a, err := Get[*api](c, "api")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("real", a.client.Call())
+	t.Assertions.Equal(1, a.client.(*countingClient).calls, "dependents should get the decorated bean")
+	t.Assertions.Equal([]string{"first", "second"}, order, "decorators should apply in order")
+}
+
+func (t *hooksTestSuit) TestDecorateError() {
+	c := t.newContainer()
+	errFailed := errors.New("failed")
+	c.Decorate("client", func(bean any) (any, error) {
+		return nil, errFailed
+	})
+
+	_, err := c.Get("api")
+	t.Assertions.ErrorIs(err, errFailed, "Get() should return decorator error")
+
+	c = t.newContainer()
+	Decorate(c, "client", func(b *bean1) (*bean1, error) {
+		return b, nil
+	})
+	_, err = c.Get("api")
+	t.Assertions.ErrorContains(err, "decorate client: type mismatch: expected *container.bean1, got container.realClient")
+}
+
+func (t *hooksTestSuit) TestHooks() {
+	c := t.newContainer()
+	errFailed := errors.New("failed")
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "broken",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return nil, errFailed
+		},
+	}))
+
+	var events []string
+	c.OnBeforeCreate(func(name string, depends map[string]any) {
+		events = append(events, "before "+name)
+		if name == "api" {
+			t.Assertions.Contains(depends, "client", "before hook should see dependencies")
+		}
+	})
+	c.Decorate("api", func(bean any) (any, error) {
+		return &named{name: "decorated"}, nil
+	})
+	child := c.NewChild()
+	child.OnAfterCreate(func(name string, depends map[string]any, bean any, err error) {
+		events = append(events, "child after "+name)
+	})
+	c.OnAfterCreate(func(name string, depends map[string]any, bean any, err error) {
+		events = append(events, "after "+name)
+		switch name {
+		case "api":
+			t.Assertions.Equal(&named{name: "decorated"}, bean, "after hook should see the decorated bean")
+		case "broken":
+			t.Assertions.ErrorIs(err, errFailed, "after hook should see the error")
+		}
+	})
+
+	_, err := c.Get("api")
+	t.Assertions.NoError(err, "Get() should not return error")
+	_, err = c.Get("broken")
+	t.Assertions.Error(err, "Get() should return error")
+	t.Assertions.Equal([]string{"before client", "after client", "before api", "after api", "before broken", "after broken"}, events)
+}
@@ -0,0 +1,151 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+)
+
+// InitAll eagerly creates the given beans, or every registered bean when no
+// name is given, together with their dependencies. Beans whose dependencies
+// are all created are built concurrently, by at most parallelism goroutines
+// (GOMAXPROCS if parallelism <= 0). The first error cancels the remaining
+// work and is returned once the running constructors finish.
+func (c *Container) InitAll(ctx context.Context, parallelism int, names ...string) error {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	plan, build, err := c.initPlan(names)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// remaining counts the dependencies of each bean not built yet
+	remaining := make(map[string]int, len(plan))
+	dependents := make(map[string][]string, len(plan))
+	var ready []string
+	for _, name := range slices.Sorted(maps.Keys(plan)) {
+		remaining[name] = len(plan[name])
+		for _, dep := range plan[name] {
+			dependents[dep] = append(dependents[dep], name)
+		}
+		if remaining[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(plan))
+	ctxDone := ctx.Done()
+	running, finished := 0, 0
+	finish := func(name string) {
+		finished++
+		for _, d := range dependents[name] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	var firstErr error
+	for running > 0 || (firstErr == nil && len(ready) > 0) {
+		for firstErr == nil && running < parallelism && len(ready) > 0 {
+			name := ready[0]
+			ready = ready[1:]
+			if !build[name] {
+				finish(name)
+				continue
+			}
+			running++
+			go func() {
+				_, err := c.GetContext(ctx, name)
+				results <- result{name, err}
+			}()
+		}
+		if running == 0 {
+			continue
+		}
+
+		select {
+		case res := <-results:
+			running--
+			if res.err != nil {
+				if firstErr == nil {
+					firstErr = res.err
+					cancel()
+				}
+				continue
+			}
+			finish(res.name)
+		case <-ctxDone:
+			ctxDone = nil
+			if firstErr == nil {
+				firstErr = ctx.Err()
+			}
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// beans left over are part of a cycle, let Get report it
+	for _, name := range slices.Sorted(maps.Keys(remaining)) {
+		if remaining[name] > 0 {
+			_, err := c.Get(name)
+			return err
+		}
+	}
+	return nil
+}
+
+// initPlan returns the beans to initialize with their resolved dependencies,
+// and which of them are to be built by this container.
+func (c *Container) initPlan(names []string) (map[string][]string, map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(names) == 0 {
+		names = c.names()
+	}
+
+	plan := make(map[string][]string)
+	build := make(map[string]bool)
+	var visit func(from *Container, name string) error
+	visit = func(from *Container, name string) error {
+		owner, beanInfo, err := from.find(name)
+		if err != nil {
+			return err
+		}
+		holder := from.holder(owner, beanInfo)
+		name = beanInfo.Name
+		if _, ok := plan[name]; ok {
+			return nil
+		}
+		plan[name] = nil
+		build[name] = beanInfo.Scope == ScopeSingleton || (beanInfo.Scope == ScopeScoped && c.parent != nil)
+
+		for _, dep := range holder.dependencies(beanInfo) {
+			if err := visit(holder, dep); err != nil {
+				return fmt.Errorf("bean %s depends on %s: %w", name, dep, err)
+			}
+			depInfo, _ := holder.lookup(dep)
+			plan[name] = append(plan[name], depInfo.Name)
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(c, name); err != nil {
+			return nil, nil, err
+		}
+	}
+	return plan, build, nil
+}
@@ -0,0 +1,159 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type initTestSuit struct {
+	suite.Suite
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(initTestSuit))
+}
+
+// tracker records the order beans are built in and how many were built at
+// the same time.
+type tracker struct {
+	mu      sync.Mutex
+	built   []string
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (tr *tracker) constructor(name string, delay time.Duration, err error) Constructor {
+	return func(depends map[string]any, params map[string]any) (interface{}, error) {
+		n := tr.current.Add(1)
+		defer tr.current.Add(-1)
+		for {
+			m := tr.max.Load()
+			if n <= m || tr.max.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+		tr.built = append(tr.built, name)
+		return name, err
+	}
+}
+
+func (t *initTestSuit) register(c *Container, tr *tracker, name string, err error, depends ...string) {
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: depends,
+		Constructor:  tr.constructor(name, 20*time.Millisecond, err),
+	}), "Register() should not return error")
+}
+
+func (t *initTestSuit) TestInitAll() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.register(c, tr, "cache", nil)
+	t.register(c, tr, "queue", nil)
+	t.register(c, tr, "repo", nil, "db", "cache")
+	t.register(c, tr, "server", nil, "repo", "queue")
+
+	t.Assertions.NoError(c.InitAll(context.Background(), 4), "InitAll() should not return error")
+
+	t.Assertions.Len(tr.built, 5, "every bean should be built")
+	t.Assertions.Equal(int32(3), tr.max.Load(), "independent beans should be built concurrently")
+	t.Assertions.Equal([]string{"repo", "server"}, tr.built[3:], "dependents should be built after their dependencies")
+}
+
+func (t *initTestSuit) TestInitAllParallelism() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.register(c, tr, "cache", nil)
+	t.register(c, tr, "queue", nil)
+
+	t.Assertions.NoError(c.InitAll(context.Background(), 1), "InitAll() should not return error")
+	t.Assertions.Equal(int32(1), tr.max.Load(), "parallelism should be respected")
+}
+
+func (t *initTestSuit) TestInitAllSubset() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.register(c, tr, "cache", nil)
+	t.register(c, tr, "repo", nil, "db")
+
+	t.Assertions.NoError(c.InitAll(context.Background(), 0, "repo"), "InitAll() should not return error")
+	t.Assertions.Equal([]string{"db", "repo"}, tr.built, "only the subset should be built")
+}
+
+func (t *initTestSuit) TestInitAllError() {
+	c := New()
+	tr := &tracker{}
+	errFailed := errors.New("failed")
+	t.register(c, tr, "db", errFailed)
+	t.register(c, tr, "repo", nil, "db")
+
+	err := c.InitAll(context.Background(), 0)
+	t.Assertions.ErrorIs(err, errFailed, "InitAll() should return constructor error")
+	t.Assertions.Equal([]string{"db"}, tr.built, "dependents should not be built")
+}
+
+func (t *initTestSuit) TestInitAllCanceled() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.register(c, tr, "repo", nil, "db")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	err := c.InitAll(ctx, 0)
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded, "InitAll() should return context error")
+	time.Sleep(30 * time.Millisecond)
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	t.Assertions.NotContains(tr.built, "repo", "remaining beans should not be built")
+}
+
+func (t *initTestSuit) TestInitAllMissing() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "repo", nil, "db")
+
+	t.Assertions.ErrorContains(c.InitAll(context.Background(), 0), "bean repo depends on db")
+	t.Assertions.Empty(tr.built, "nothing should be built")
+}
+
+func (t *initTestSuit) TestInitAllCircular() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.register(c, tr, "a", nil, "b", "db")
+	t.register(c, tr, "b", nil, "a")
+
+	t.Assertions.ErrorContains(c.InitAll(context.Background(), 0), "circular dependency")
+}
+
+func (t *initTestSuit) TestInitAllSkipsNonSingletons() {
+	c := New()
+	tr := &tracker{}
+	t.register(c, tr, "db", nil)
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "request",
+		Dependencies: []string{"db"},
+		Scope:        ScopeScoped,
+		Constructor:  tr.constructor("request", 0, nil),
+	}), "Register() should not return error")
+
+	t.Assertions.NoError(c.InitAll(context.Background(), 0), "InitAll() should not return error")
+	t.Assertions.Equal([]string{"db"}, tr.built, "scoped beans should not be built in the root")
+
+	t.Assertions.NoError(c.NewScope().InitAll(context.Background(), 0), "InitAll() should not return error")
+	t.Assertions.Equal([]string{"db", "request"}, tr.built, "scoped beans should be built in a scope")
+}
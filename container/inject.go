@@ -0,0 +1,104 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Inject fills the exported fields of the struct pointed to by target that
+// carry an `inject` tag:
+//
+//	DB    *sql.DB `inject:"db"`             // bean named db
+//	Cache *Cache  `inject:""`               // bean resolved by the field type
+//	Stats Stats   `inject:"stats,optional"` // left untouched if not registered
+func (c *Container) Inject(target any) error {
+	return c.inject(&resolution{ctx: context.Background()}, target)
+}
+
+func (c *Container) inject(r *resolution, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("inject target must be a non-nil struct pointer, got %T", target)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("inject")
+		if !ok {
+			continue
+		}
+		if !f.IsExported() {
+			return fmt.Errorf("inject %s.%s: field is not exported", t.Name(), f.Name)
+		}
+
+		name, optional, err := injectTag(f, tag)
+		if err != nil {
+			return fmt.Errorf("inject %s.%s: %w", t.Name(), f.Name, err)
+		}
+		if optional && !c.exists(name) {
+			continue
+		}
+
+		bean, err := c.get(r, name)
+		if err != nil {
+			return fmt.Errorf("inject %s.%s: %w", t.Name(), f.Name, err)
+		}
+		value, err := argValue(bean, f.Type)
+		if err != nil {
+			return fmt.Errorf("inject %s.%s: %w", t.Name(), f.Name, err)
+		}
+		v.Field(i).Set(value)
+	}
+	return nil
+}
+
+// injectTag parses the `inject` tag of f into the name of the bean to inject
+// and whether it is optional.
+func injectTag(f reflect.StructField, tag string) (string, bool, error) {
+	name, option, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = typeName(f.Type)
+	}
+	switch option {
+	case "":
+		return name, false, nil
+	case "optional":
+		return name, true, nil
+	default:
+		return "", false, fmt.Errorf("unknown inject option %q", option)
+	}
+}
+
+// checkInjectTags reports the invalid `inject` tags of the struct type t, or
+// the struct it points to.
+func checkInjectTags(t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if tag, ok := f.Tag.Lookup("inject"); ok {
+			if _, _, err := injectTag(f, tag); err != nil {
+				return fmt.Errorf("inject %s.%s: %w", t.Name(), f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// exists reports whether a bean is registered under name or type name in c
+// or its parents. Ambiguous names count as existing.
+func (c *Container) exists(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _, err := c.find(name)
+	return !errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,109 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type injectTestSuit struct {
+	suite.Suite
+}
+
+func TestInject(t *testing.T) {
+	suite.Run(t, new(injectTestSuit))
+}
+
+type handler struct {
+	DB      *db    `inject:""`
+	Cache   *cache `inject:"cache"`
+	Missing *bean1 `inject:"missing,optional"`
+	Other   string
+}
+
+func (t *injectTestSuit) newContainer() *Container {
+	c := New()
+	t.Assertions.NoError(c.Provide(newDB), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newCache, WithName("cache")), "Provide() should not return error")
+	return c
+}
+
+func (t *injectTestSuit) TestInject() {
+	c := t.newContainer()
+
+	h := &handler{Other: "other"}
+	t.Assertions.NoError(c.Inject(h), "Inject() should not return error")
+	t.Assertions.NotNil(h.DB, "DB should be injected by type")
+	t.Assertions.Same(h.DB, h.Cache.db, "DB should be shared")
+	t.Assertions.Nil(h.Missing, "optional field should be skipped")
+	t.Assertions.Equal("other", h.Other, "untagged field should be untouched")
+}
+
+func (t *injectTestSuit) TestInjectRequiredMissing() {
+	c := New()
+	t.Assertions.Error(c.Inject(&handler{}), "Inject() should return error")
+}
+
+func (t *injectTestSuit) TestInjectTypeMismatch() {
+	c := t.newContainer()
+	target := &struct {
+		DB *cache `inject:"cache"`
+		X  *db    `inject:"cache"`
+	}{}
+	t.Assertions.ErrorContains(c.Inject(target), "type mismatch")
+}
+
+func (t *injectTestSuit) TestInjectInvalidTarget() {
+	c := t.newContainer()
+	t.Assertions.Error(c.Inject(handler{}), "Inject() should reject non-pointer")
+	t.Assertions.Error(c.Inject((*handler)(nil)), "Inject() should reject nil")
+	t.Assertions.Error(c.Inject(&struct {
+		db *db `inject:""`
+	}{}), "Inject() should reject unexported fields")
+}
+
+func (t *injectTestSuit) TestInjectFields() {
+	c := t.newContainer()
+	err := c.Register(BeanInfo{
+		Name:         "handler",
+		InjectFields: true,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &handler{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	h, err := Get[*handler](c, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotNil(h.DB, "DB should be injected")
+	t.Assertions.NotNil(h.Cache, "Cache should be injected")
+}
+
+func (t *injectTestSuit) TestInjectFieldsCircular() {
+	c := New()
+	err := c.Register(BeanInfo{
+		Name:         "self",
+		InjectFields: true,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &struct {
+				Self any `inject:"self"`
+			}{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	_, err = c.Get("self")
+	t.Assertions.ErrorContains(err, "circular dependency")
+}
+
+type misspelled struct {
+	DB *db `inject:"x,optinal"`
+}
+
+func (t *injectTestSuit) TestInjectUnknownOption() {
+	c := t.newContainer()
+	t.Assertions.EqualError(c.Inject(&misspelled{}), `inject misspelled.DB: unknown inject option "optinal"`)
+
+	err := c.Provide(func() *misspelled { return &misspelled{} }, WithInjectFields())
+	t.Assertions.EqualError(err, `inject misspelled.DB: unknown inject option "optinal"`, "Register() should reject invalid tags")
+}
@@ -0,0 +1,77 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// Provider resolves a lazily declared dependency each time it is called.
+type Provider[T any] func() (T, error)
+
+// Lazy returns the provider of the lazy dependency name found in depends,
+// converted to a Provider[T].
+func Lazy[T any](depends map[string]any, name string) Provider[T] {
+	p, ok := depends[name].(Provider[any])
+	return func() (T, error) {
+		var t T
+		if !ok {
+			return t, fmt.Errorf("not a lazy dependency: %s", name)
+		}
+		bean, err := p()
+		if err != nil {
+			return t, err
+		}
+		if bean == nil {
+			return t, nil
+		}
+		if t, ok := bean.(T); ok {
+			return t, nil
+		}
+		return t, mismatch(name, reflect.TypeFor[T](), bean)
+	}
+}
+
+// provider returns the Provider of the lazy dependency name of the bean r is
+// creating. Called while the bean is being constructed, it continues the
+// chain of r so that cycles are still detected; called afterwards, it starts
+// a new chain.
+func (c *Container) provider(r *resolution, name string, building *atomic.Bool) Provider[any] {
+	path := slices.Clone(r.path)
+	singleton := r.singleton
+	ctx := r.ctx
+	trigger := r.trigger
+	return func() (any, error) {
+		lr := &resolution{ctx: context.Background(), trigger: name, singleton: singleton}
+		if building.Load() {
+			lr.ctx = ctx
+			lr.trigger = trigger
+			lr.path = slices.Clone(path)
+			lr.parent = r
+		}
+		return c.get(lr, name)
+	}
+}
+
+// isProviderType reports whether t has the shape of a Provider, a
+// func() (T, error).
+func isProviderType(t reflect.Type) bool {
+	return t.Kind() == reflect.Func && t.NumIn() == 0 && t.NumOut() == 2 && t.Out(1) == errorType
+}
+
+// providerValue adapts p to the provider type t, see isProviderType.
+func providerValue(p Provider[any], t reflect.Type) reflect.Value {
+	return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+		bean, err := p()
+		value := reflect.Zero(t.Out(0))
+		if err == nil {
+			value, err = argValue(bean, t.Out(0))
+		}
+		if err != nil {
+			return []reflect.Value{reflect.Zero(t.Out(0)), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{value, reflect.Zero(errorType)}
+	})
+}
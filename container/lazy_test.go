@@ -0,0 +1,137 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type lazyTestSuit struct {
+	suite.Suite
+}
+
+func TestLazy(t *testing.T) {
+	suite.Run(t, new(lazyTestSuit))
+}
+
+type bus struct {
+	subscribers Provider[*subscriber]
+}
+
+type subscriber struct {
+	bus *bus
+}
+
+func (t *lazyTestSuit) newContainer() *Container {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:             "bus",
+		LazyDependencies: []string{"subscriber"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bus{subscribers: Lazy[*subscriber](depends, "subscriber")}, nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "subscriber",
+		Dependencies: []string{"bus"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &subscriber{bus: depends["bus"].(*bus)}, nil
+		},
+	}))
+	return c
+}
+
+func (t *lazyTestSuit) TestCycle() {
+	c := t.newContainer()
+	t.Assertions.NoError(c.Validate(), "lazy dependencies should not count for cycles")
+
+	b, err := Get[*bus](c, "bus")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotContains(c.beans, "subscriber", "lazy dependency should not be created eagerly")
+
+	s, err := b.subscribers()
+	t.Assertions.NoError(err, "Provider should not return error")
+	t.Assertions.Same(b, s.bus, "the cycle should be closed")
+	s2, err := b.subscribers()
+	t.Assertions.NoError(err, "Provider should not return error")
+	t.Assertions.Same(s, s2, "the provider should return the singleton")
+}
+
+func (t *lazyTestSuit) TestCycleDuringConstruction() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:             "bus",
+		LazyDependencies: []string{"subscriber"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			_, err := Lazy[*subscriber](depends, "subscriber")()
+			return &bus{}, err
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "subscriber",
+		Dependencies: []string{"bus"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &subscriber{}, nil
+		},
+	}))
+
+	_, err := c.Get("bus")
+	t.Assertions.ErrorContains(err, "circular dependency", "calling the provider during construction should detect cycles")
+}
+
+func (t *lazyTestSuit) TestLazyErrors() {
+	c := t.newContainer()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:             "consumer",
+		LazyDependencies: []string{"missing", "bus"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return depends, nil
+		},
+	}))
+	t.Assertions.ErrorContains(c.Validate(), "bean consumer depends lazily on missing: bean not found: missing")
+
+	depends, err := Get[map[string]any](c, "consumer")
+	t.Assertions.NoError(err, "Get() should not return error")
+	_, err = Lazy[*bus](depends, "missing")()
+	t.Assertions.ErrorContains(err, "bean not found: missing")
+	_, err = Lazy[*subscriber](depends, "bus")()
+	t.Assertions.ErrorContains(err, "type mismatch")
+	_, err = Lazy[*bus](depends, "other")()
+	t.Assertions.ErrorContains(err, "not a lazy dependency: other")
+}
+
+type eventBus struct {
+	handlers func() (*handlers, error)
+}
+
+type handlers struct {
+	bus *eventBus
+}
+
+func (t *lazyTestSuit) TestProvide() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func(h Provider[*handlers]) *eventBus {
+		return &eventBus{handlers: h}
+	}))
+	t.Assertions.NoError(c.Provide(func(b *eventBus) *handlers {
+		return &handlers{bus: b}
+	}))
+	t.Assertions.NoError(c.Validate(), "Validate() should not return error")
+
+	b, err := Get[*eventBus](c, typeName(reflect.TypeOf(&eventBus{})))
+	t.Assertions.NoError(err, "Get() should not return error")
+	h, err := b.handlers()
+	t.Assertions.NoError(err, "Provider should not return error")
+	t.Assertions.Same(b, h.bus, "the cycle should be closed")
+}
+
+func (t *lazyTestSuit) TestGraph() {
+	g := t.newContainer().Graph()
+	t.Assertions.Equal([]GraphEdge{
+		{From: "bus", To: "subscriber", Lazy: true},
+		{From: "subscriber", To: "bus"},
+	}, g.Edges)
+	t.Assertions.Contains(g.DOT(), `"bus" -> "subscriber" [style=dashed];`)
+	t.Assertions.Contains(g.Mermaid(), "n0 -.-> n1")
+}
@@ -0,0 +1,84 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Stopper is implemented by beans that need a context to shut down.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// Close releases every created bean in reverse creation order, so a bean is
+// closed before its dependencies. Beans implementing Stopper are stopped and
+// beans implementing io.Closer are closed; if ctx is done before all beans
+// are released, the remaining ones are reported as errors. Beans whose
+// construction is in flight are closed once built, and their Get calls fail
+// with ErrClosed. A child holding beans is referenced by its parent until it
+// is closed.
+func (c *Container) Close(ctx context.Context) error {
+	c.mu.Lock()
+	beans, order := c.beans, c.order
+	c.beans = make(map[string]any)
+	c.order = nil
+	c.replaced = nil
+	c.epoch++
+	// c may have been moved up by the close of a parent
+	for x := c.parent; x != nil; x = x.parent {
+		delete(x.children, c)
+	}
+	if c.parent != nil {
+		// children of c holding beans stay reachable from the parent
+		for child := range c.children {
+			c.parent.children[child] = struct{}{}
+		}
+	}
+	c.mu.Unlock()
+
+	return closeBeans(ctx, order, beans)
+}
+
+// closeBeans releases the beans named in order, last first.
+func closeBeans(ctx context.Context, order []string, beans map[string]any) error {
+	var errs []error
+	for _, name := range slices.Backward(order) {
+		if err := closeBean(ctx, beans[name]); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func closeBean(ctx context.Context, bean any) error {
+	stopper, isStopper := bean.(Stopper)
+	closer, isCloser := bean.(io.Closer)
+	if !isStopper && !isCloser {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		if isStopper {
+			errs = append(errs, stopper.Stop(ctx))
+		}
+		if isCloser {
+			errs = append(errs, closer.Close())
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,133 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type lifecycleTestSuit struct {
+	suite.Suite
+}
+
+func TestLifecycle(t *testing.T) {
+	suite.Run(t, new(lifecycleTestSuit))
+}
+
+type closeRecorder struct {
+	name   string
+	closed *[]string
+	err    error
+	delay  time.Duration
+}
+
+func (r *closeRecorder) Close() error {
+	time.Sleep(r.delay)
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}
+
+type stopRecorder struct {
+	name    string
+	stopped *[]string
+}
+
+func (r *stopRecorder) Stop(ctx context.Context) error {
+	*r.stopped = append(*r.stopped, r.name)
+	return nil
+}
+
+func (t *lifecycleTestSuit) register(c *Container, name string, depends []string, bean any) {
+	err := c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: depends,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return bean, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+}
+
+func (t *lifecycleTestSuit) TestCloseReverseOrder() {
+	var closed []string
+	c := New()
+	t.register(c, "db", nil, &closeRecorder{name: "db", closed: &closed})
+	t.register(c, "cache", []string{"db"}, &stopRecorder{name: "cache", stopped: &closed})
+	t.register(c, "server", []string{"db", "cache"}, &closeRecorder{name: "server", closed: &closed})
+	t.register(c, "unused", nil, &closeRecorder{name: "unused", closed: &closed})
+
+	_, err := c.Get("server")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	t.Assertions.NoError(c.Close(context.Background()), "Close() should not return error")
+	t.Assertions.Equal([]string{"server", "cache", "db"}, closed, "beans should be closed in reverse order")
+	t.Assertions.Empty(c.beans, "Close() should drop created beans")
+}
+
+func (t *lifecycleTestSuit) TestCloseCollectsErrors() {
+	var closed []string
+	errDB := errors.New("db failed")
+	errServer := errors.New("server failed")
+	c := New()
+	t.register(c, "db", nil, &closeRecorder{name: "db", closed: &closed, err: errDB})
+	t.register(c, "server", []string{"db"}, &closeRecorder{name: "server", closed: &closed, err: errServer})
+
+	_, err := c.Get("server")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	err = c.Close(context.Background())
+	t.Assertions.ErrorIs(err, errDB, "Close() should return db error")
+	t.Assertions.ErrorIs(err, errServer, "Close() should return server error")
+	t.Assertions.Equal([]string{"server", "db"}, closed, "all beans should be closed")
+}
+
+func (t *lifecycleTestSuit) TestCloseDeadline() {
+	var closed []string
+	c := New()
+	t.register(c, "db", nil, &closeRecorder{name: "db", closed: &closed})
+	t.register(c, "server", []string{"db"}, &closeRecorder{name: "server", closed: &closed, delay: time.Second})
+
+	_, err := c.Get("server")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = c.Close(ctx)
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded, "Close() should respect the deadline")
+	t.Assertions.ErrorContains(err, "close server", "Close() should name the slow bean")
+	t.Assertions.ErrorContains(err, "close db", "Close() should name the skipped bean")
+}
+
+func (t *lifecycleTestSuit) TestCloseDuringConstruction() {
+	var closed []string
+	started, release := make(chan struct{}), make(chan struct{})
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "db",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			close(started)
+			<-release
+			return &closeRecorder{name: "db", closed: &closed}, nil
+		},
+	}))
+
+	done := make(chan error)
+	go func() {
+		_, err := c.Get("db")
+		done <- err
+	}()
+	<-started
+	t.Assertions.NoError(c.Close(context.Background()), "Close() should not return error")
+	close(release)
+
+	err := <-done
+	t.Assertions.ErrorIs(err, ErrClosed, "Get() should fail when the container is closed meanwhile")
+	t.Assertions.EqualError(err, "create db: container closed")
+	t.Assertions.Equal([]string{"db"}, closed, "the late bean should be closed")
+	c.mu.Lock()
+	t.Assertions.Empty(c.beans, "the late bean should not be cached")
+	c.mu.Unlock()
+}
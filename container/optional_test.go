@@ -0,0 +1,139 @@
+package container
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type optionalTestSuit struct {
+	suite.Suite
+}
+
+func TestOptional(t *testing.T) {
+	suite.Run(t, new(optionalTestSuit))
+}
+
+// register registers a bean listing the dependencies it was built from,
+// telling absent ones apart from nil ones.
+func (t *optionalTestSuit) register(c *Container, beanInfo BeanInfo) {
+	beanInfo.Constructor = func(depends map[string]any, params map[string]any) (interface{}, error) {
+		var values []string
+		for _, dep := range slices.Concat(beanInfo.Dependencies, beanInfo.OptionalDependencies) {
+			if bean, ok := depends[dep]; !ok {
+				values = append(values, dep+"=absent")
+			} else if bean == nil {
+				values = append(values, dep+"=nil")
+			} else {
+				values = append(values, dep+"="+bean.(string))
+			}
+		}
+		return strings.Join(values, ","), nil
+	}
+	t.Assertions.NoError(c.Register(beanInfo))
+}
+
+func (t *optionalTestSuit) value(c *Container, name string, value any) {
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: name,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return value, nil
+		},
+	}))
+}
+
+func (t *optionalTestSuit) TestOptional() {
+	c := New()
+	t.value(c, "db", "postgres")
+	t.value(c, "cache", nil)
+	t.register(c, BeanInfo{
+		Name:                 "service",
+		Dependencies:         []string{"db"},
+		OptionalDependencies: []string{"cache", "metrics"},
+	})
+
+	t.Assertions.NoError(c.Validate(), "missing optional dependencies should be valid")
+	t.Assertions.NotContains(c.Graph().Edges, GraphEdge{From: "service", To: "metrics"})
+	service, err := Get[string](c, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("db=postgres,cache=nil,metrics=absent", service, "absent and nil dependencies should be told apart")
+}
+
+func (t *optionalTestSuit) TestFallbacks() {
+	c := New()
+	t.value(c, "noopMetrics", "noop")
+	t.value(c, "memCache", "mem")
+	t.register(c, BeanInfo{
+		Name:                 "service",
+		Dependencies:         []string{"cache"},
+		OptionalDependencies: []string{"metrics"},
+		Fallbacks:            map[string]string{"cache": "memCache", "metrics": "noopMetrics"},
+	})
+
+	t.Assertions.NoError(c.Validate(), "Validate() should not return error")
+	t.Assertions.Contains(c.Graph().Edges, GraphEdge{From: "service", To: "memCache"})
+	service, err := Get[string](c, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("cache=mem,metrics=noop", service, "fallbacks should be passed under the dependency name")
+
+	child := c.NewChild()
+	t.value(child, "metrics", "prometheus")
+	t.register(child, BeanInfo{
+		Name:                 "handler",
+		OptionalDependencies: []string{"metrics"},
+		Fallbacks:            map[string]string{"metrics": "noopMetrics"},
+	})
+	handler, err := Get[string](child, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("metrics=prometheus", handler, "existing dependencies should win over fallbacks")
+}
+
+func (t *optionalTestSuit) TestMissing() {
+	c := New()
+	t.register(c, BeanInfo{
+		Name:         "service",
+		Dependencies: []string{"cache"},
+		Fallbacks:    map[string]string{"cache": "memCache"},
+	})
+	t.Assertions.EqualError(c.Validate(), "bean service depends on memCache: bean not found: memCache")
+	_, err := c.Get("service")
+	t.Assertions.EqualError(err, "bean not found: memCache")
+}
+
+func (t *optionalTestSuit) TestConditional() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:       "metrics",
+		Conditions: []Condition{OnProfile("prod")},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return "prometheus", nil
+		},
+	}))
+	t.register(c, BeanInfo{Name: "service", OptionalDependencies: []string{"metrics"}})
+
+	service, err := Get[string](c, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("metrics=absent", service, "dependencies without matching definition should be absent")
+}
+
+func (t *optionalTestSuit) TestConfig() {
+	c := New()
+	t.value(c, "memCache", "mem")
+	t.Assertions.NoError(c.RegisterFactory("service", func(depends map[string]any, params map[string]any) (interface{}, error) {
+		_, ok := depends["metrics"]
+		return depends["cache"].(string) + "," + map[bool]string{true: "metrics", false: "no metrics"}[ok], nil
+	}))
+	t.Assertions.NoError(c.LoadConfig(strings.NewReader(`
+beans:
+  - name: service
+    factory: service
+    optional_dependencies: [metrics, cache]
+    fallbacks: {cache: memCache}
+`), ConfigFormatYAML))
+
+	service, err := Get[string](c, "service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("mem,no metrics", service)
+}
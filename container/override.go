@@ -0,0 +1,138 @@
+package container
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Override replaces the definition of a bean in c, or adds it if c does not
+// define it yet, shadowing any definition of its parents. The bean and every
+// bean that depends on it, directly or transitively, are dropped from the
+// caches of c and its children so that they are rebuilt on the next Get;
+// constructions in flight are built again. Dropped beans are not closed.
+func (c *Container) Override(beanInfo BeanInfo) error {
+	if err := checkBeanInfo(beanInfo); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.undefine(beanInfo.Name)
+	c.define(beanInfo)
+	c.invalidate(beanInfo.Name)
+	return nil
+}
+
+// Replace overrides the bean name with a fixed instance, see Override. The
+// replacement keeps the Type of the definition it replaces, if any, so it is
+// still found by type.
+func (c *Container) Replace(name string, bean any) error {
+	c.mu.Lock()
+	beanInfo, err := c.lookup(name)
+	c.mu.Unlock()
+
+	t := reflect.TypeOf(bean)
+	if err == nil && beanInfo.Type != nil {
+		t = beanInfo.Type
+	}
+	return c.Override(BeanInfo{
+		Name: name,
+		Type: t,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return bean, nil
+		},
+	})
+}
+
+// cached is a bean dropped from the cache of c.
+type cached struct {
+	c    *Container
+	name string
+	bean any
+}
+
+// beanKey identifies a bean by the container caching it, or resolving it
+// for prototypes.
+type beanKey struct {
+	c    *Container
+	name string
+}
+
+// invalidate drops name and its dependents, direct or transitive, from the
+// caches of c and its children, and returns them parents first, each
+// container's in creation order. Dependents are found from the definitions,
+// so that those reached through prototypes are dropped too, and their
+// constructions in flight are marked stale. Must be called with c.mu held.
+func (c *Container) invalidate(name string) []cached {
+	containers := c.subtree()
+	visited := map[beanKey]struct{}{{c, name}: {}}
+	queue := []beanKey{{c, name}}
+	dependents := make(map[beanKey][]beanKey)
+	for _, x := range containers {
+		for _, n := range x.names() {
+			owner, beanInfo, err := x.find(n)
+			if err != nil || x.holder(owner, beanInfo) != x {
+				continue
+			}
+			if n == name && owner == c {
+				key := beanKey{x, n}
+				if _, ok := visited[key]; !ok {
+					visited[key] = struct{}{}
+					queue = append(queue, key)
+				}
+			}
+			for _, dep := range x.dependencies(beanInfo) {
+				depOwner, depInfo, err := x.find(dep)
+				if err != nil {
+					continue
+				}
+				key := beanKey{x.holder(depOwner, depInfo), depInfo.Name}
+				dependents[key] = append(dependents[key], beanKey{x, n})
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[key] {
+			if _, ok := visited[d]; !ok {
+				visited[d] = struct{}{}
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	var dropped []cached
+	for _, x := range containers {
+		x.order = slices.DeleteFunc(x.order, func(n string) bool {
+			if _, ok := visited[beanKey{x, n}]; ok {
+				dropped = append(dropped, cached{x, n, x.beans[n]})
+				return true
+			}
+			return false
+		})
+	}
+	for key := range visited {
+		if key.c.gens == nil {
+			key.c.gens = make(map[string]uint64)
+		}
+		key.c.gens[key.name]++
+		delete(key.c.beans, key.name)
+		delete(key.c.failed, key.name)
+	}
+	return dropped
+}
+
+// subtree returns c and its children holding beans, parents first. Must be
+// called with c.mu held.
+func (c *Container) subtree() []*Container {
+	containers := []*Container{c}
+	for i := 0; i < len(containers); i++ {
+		for child := range containers[i].children {
+			containers = append(containers, child)
+		}
+	}
+	return containers
+}
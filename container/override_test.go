@@ -0,0 +1,196 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type overrideTestSuit struct {
+	suite.Suite
+}
+
+func TestOverride(t *testing.T) {
+	suite.Run(t, new(overrideTestSuit))
+}
+
+type client interface {
+	Call() string
+}
+
+type realClient struct{}
+
+func (realClient) Call() string { return "real" }
+
+type fakeClient struct{}
+
+func (fakeClient) Call() string { return "fake" }
+
+type api struct {
+	client client
+}
+
+func (t *overrideTestSuit) newContainer() *Container {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "client",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return realClient{}, nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "api",
+		Dependencies: []string{"client"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &api{client: depends["client"].(client)}, nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "handler",
+		Dependencies: []string{"api"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &named{depends: depends}, nil
+		},
+	}))
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "unrelated",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean1{}, nil
+		},
+	}))
+	return c
+}
+
+func (t *overrideTestSuit) TestReplaceInvalidatesDependents() {
+	c := t.newContainer()
+	h1, err := Get[*named](c, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+	u1, err := c.Get("unrelated")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("real", h1.depends["api"].(*api).client.Call())
+
+	t.Assertions.NoError(c.Replace("client", fakeClient{}), "Replace() should not return error")
+	t.Assertions.Equal([]string{"unrelated"}, c.order, "dependents should be dropped")
+
+	h2, err := Get[*named](c, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+	u2, err := c.Get("unrelated")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotSame(h1, h2, "dependents should be rebuilt")
+	t.Assertions.Equal("fake", h2.depends["api"].(*api).client.Call())
+	t.Assertions.Same(u1, u2, "unrelated beans should be kept")
+}
+
+func (t *overrideTestSuit) TestOverride() {
+	c := t.newContainer()
+	_, err := c.Get("api")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	err = c.Override(BeanInfo{
+		Name: "client",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return fakeClient{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Override() should not return error")
+
+	a, err := Get[*api](c, "api")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("fake", a.client.Call())
+
+	t.Assertions.Error(c.Override(BeanInfo{Name: "client"}), "Override() should require a constructor")
+}
+
+func (t *overrideTestSuit) TestReplaceKeepsType() {
+	c := New()
+	t.Assertions.NoError(c.Provide(newDB))
+	t.Assertions.NoError(c.Provide(newCache))
+	fake := &db{dsn: "fake"}
+
+	t.Assertions.NoError(c.Replace(typeName(reflect.TypeOf(&db{})), fake), "Replace() should not return error")
+	cc, err := Get[*cache](c, typeName(reflect.TypeOf(&cache{})))
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Same(fake, cc.db, "the replacement should be resolved by type")
+}
+
+func (t *overrideTestSuit) TestOverrideInChild() {
+	parent := t.newContainer()
+	child := parent.NewChild()
+	t.Assertions.NoError(child.Replace("client", fakeClient{}), "Replace() should not return error")
+
+	c1, err := Get[client](parent, "client")
+	t.Assertions.NoError(err, "Get() should not return error")
+	c2, err := Get[client](child, "client")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("real", c1.Call(), "parent should be untouched")
+	t.Assertions.Equal("fake", c2.Call(), "child should see the replacement")
+}
+
+func (t *overrideTestSuit) TestReplaceThroughPrototype() {
+	c := t.newContainer()
+	t.Assertions.NoError(c.Override(BeanInfo{
+		Name:         "api",
+		Scope:        ScopePrototype,
+		Dependencies: []string{"client"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &api{client: depends["client"].(client)}, nil
+		},
+	}))
+	h1, err := Get[*named](c, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	t.Assertions.NoError(c.Replace("client", fakeClient{}), "Replace() should not return error")
+	h2, err := Get[*named](c, "handler")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotSame(h1, h2, "dependents reached through prototypes should be rebuilt")
+	t.Assertions.Equal("fake", h2.depends["api"].(*api).client.Call())
+}
+
+func (t *overrideTestSuit) TestReplaceInChildren() {
+	c := t.newContainer()
+	child := c.NewChild()
+	t.Assertions.NoError(child.Register(BeanInfo{
+		Name:         "session",
+		Scope:        ScopeScoped,
+		Dependencies: []string{"api"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &named{depends: depends}, nil
+		},
+	}))
+	s1, err := Get[*named](child, "session")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	t.Assertions.NoError(c.Replace("client", fakeClient{}), "Replace() should not return error")
+	s2, err := Get[*named](child, "session")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotSame(s1, s2, "dependents cached in children should be rebuilt")
+	t.Assertions.Equal("fake", s2.depends["api"].(*api).client.Call())
+}
+
+func (t *overrideTestSuit) TestReplaceDuringConstruction() {
+	started, release := make(chan struct{}), make(chan struct{})
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "client",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			close(started)
+			<-release
+			return realClient{}, nil
+		},
+	}))
+
+	done := make(chan any)
+	go func() {
+		bean, _ := c.Get("client")
+		done <- bean
+	}()
+	<-started
+	t.Assertions.NoError(c.Replace("client", fakeClient{}), "Replace() should not return error")
+	close(release)
+
+	t.Assertions.Equal(fakeClient{}, <-done, "Get() in flight should return the replacement")
+	bean, err := c.Get("client")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal(fakeClient{}, bean, "the outdated bean should not be cached")
+}
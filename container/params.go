@@ -0,0 +1,395 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Params adapts a constructor taking its params as a typed struct, decoded
+// with DecodeParams, into a Constructor.
+func Params[T any](constructor func(depends map[string]any, params T) (interface{}, error)) Constructor {
+	return func(depends map[string]any, params map[string]any) (interface{}, error) {
+		var p T
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return constructor(depends, p)
+	}
+}
+
+// DecodeParams decodes params into the struct pointed to by target. Fields
+// are matched by their `param` tag, or case-insensitively by field name, and
+// can be configured with tags:
+//
+//	Addr    string        `param:"addr,required"`
+//	Timeout time.Duration `param:"timeout" default:"5s" validate:"min=1s"`
+//	Buffer  ByteSize      `param:"buffer" default:"4KiB"`
+//	Mode    string        `param:"mode" default:"fast" validate:"oneof=fast safe"`
+//
+// Strings are converted to numbers, bools, durations, sizes and
+// comma-separated slices, and numbers to any numeric type that holds them.
+// Nested structs are decoded from nested maps. Validation rules are min and
+// max, comparing values of numbers and durations and lengths of strings,
+// slices and maps, and oneof, a space-separated list of allowed values.
+func DecodeParams(params map[string]any, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("params target must be a non-nil struct pointer, got %T", target)
+	}
+	return decodeStruct("", params, v.Elem())
+}
+
+func decodeStruct(prefix string, params map[string]any, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("param")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		key := prefix + name
+
+		raw, ok := lookupParam(params, name)
+		if !ok {
+			if def, ok := f.Tag.Lookup("default"); ok {
+				raw = def
+			} else if options == "required" {
+				return fmt.Errorf("param %s: required", key)
+			} else {
+				continue
+			}
+		}
+
+		if err := decodeValue(key, raw, v.Field(i)); err != nil {
+			return err
+		}
+		if rules, ok := f.Tag.Lookup("validate"); ok {
+			if err := validateValue(rules, v.Field(i)); err != nil {
+				return fmt.Errorf("param %s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// lookupParam finds a param by exact name, then ignoring case. Nil values
+// count as missing.
+func lookupParam(params map[string]any, name string) (any, bool) {
+	if raw, ok := params[name]; ok {
+		return raw, raw != nil
+	}
+	for key, raw := range params {
+		if strings.EqualFold(key, name) {
+			return raw, raw != nil
+		}
+	}
+	return nil, false
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func decodeValue(key string, raw any, v reflect.Value) error {
+	if rv := reflect.ValueOf(raw); rv.Type().AssignableTo(v.Type()) && v.Kind() != reflect.Struct {
+		v.Set(rv)
+		return nil
+	}
+
+	var err error
+	switch {
+	case v.Type() == durationType:
+		err = decodeDuration(raw, v)
+	case v.Type() == byteSizeType:
+		err = decodeByteSize(raw, v)
+	case v.Kind() == reflect.Struct:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("param %s: expected map, got %T", key, raw)
+		}
+		return decodeStruct(key+".", m, v)
+	case v.Kind() == reflect.Slice:
+		return decodeSlice(key, raw, v)
+	case v.Kind() == reflect.Map:
+		return decodeMap(key, raw, v)
+	default:
+		err = decodeScalar(raw, v)
+	}
+	if err != nil {
+		return fmt.Errorf("param %s: %w", key, err)
+	}
+	return nil
+}
+
+func decodeScalar(raw any, v reflect.Value) error {
+	s, isString := raw.(string)
+	switch v.Kind() {
+	case reflect.String:
+		switch raw.(type) {
+		case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			v.SetString(fmt.Sprint(raw))
+			return nil
+		}
+	case reflect.Bool:
+		if isString {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("invalid bool %q", s)
+			}
+			v.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isString {
+			n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("invalid %s %q", v.Type(), s)
+			}
+			v.SetInt(n)
+			return nil
+		}
+		if f, ok := toFloat(raw); ok {
+			if f != math.Trunc(f) || v.OverflowInt(int64(f)) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%v does not fit in %s", raw, v.Type())
+			}
+			v.SetInt(int64(f))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isString {
+			n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("invalid %s %q", v.Type(), s)
+			}
+			v.SetUint(n)
+			return nil
+		}
+		if f, ok := toFloat(raw); ok {
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+				return fmt.Errorf("%v does not fit in %s", raw, v.Type())
+			}
+			v.SetUint(uint64(f))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if isString {
+			f, err := strconv.ParseFloat(s, v.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("invalid %s %q", v.Type(), s)
+			}
+			v.SetFloat(f)
+			return nil
+		}
+		if f, ok := toFloat(raw); ok {
+			v.SetFloat(f)
+			return nil
+		}
+	case reflect.Interface:
+		if reflect.TypeOf(raw).Implements(v.Type()) {
+			v.Set(reflect.ValueOf(raw))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot decode %T into %s", raw, v.Type())
+}
+
+func toFloat(raw any) (float64, bool) {
+	switch n := reflect.ValueOf(raw); n.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(n.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(n.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return n.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func decodeDuration(raw any, v reflect.Value) error {
+	s, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("expected duration string, got %T", raw)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	v.SetInt(int64(d))
+	return nil
+}
+
+func decodeByteSize(raw any, v reflect.Value) error {
+	if s, ok := raw.(string); ok {
+		size, err := ParseByteSize(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(size))
+		return nil
+	}
+	return decodeScalar(raw, v)
+}
+
+func decodeSlice(key string, raw any, v reflect.Value) error {
+	var items []any
+	switch raw := raw.(type) {
+	case string:
+		for _, item := range strings.Split(raw, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+	default:
+		rv := reflect.ValueOf(raw)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Errorf("param %s: expected list, got %T", key, raw)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i).Interface())
+		}
+	}
+
+	s := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		if err := decodeValue(fmt.Sprintf("%s[%d]", key, i), item, s.Index(i)); err != nil {
+			return err
+		}
+	}
+	v.Set(s)
+	return nil
+}
+
+func decodeMap(key string, raw any, v reflect.Value) error {
+	m, ok := raw.(map[string]any)
+	if !ok || v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("param %s: cannot decode %T into %s", key, raw, v.Type())
+	}
+	out := reflect.MakeMapWithSize(v.Type(), len(m))
+	for k, item := range m {
+		value := reflect.New(v.Type().Elem()).Elem()
+		if item != nil {
+			if err := decodeValue(key+"."+k, item, value); err != nil {
+				return err
+			}
+		}
+		out.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), value)
+	}
+	v.Set(out)
+	return nil
+}
+
+func validateValue(rules string, v reflect.Value) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "min", "max":
+			n, limit, err := compareValues(v, arg)
+			if err != nil {
+				return fmt.Errorf("invalid rule %q: %w", rule, err)
+			}
+			if name == "min" && n < limit {
+				return fmt.Errorf("must be at least %s", arg)
+			}
+			if name == "max" && n > limit {
+				return fmt.Errorf("must be at most %s", arg)
+			}
+		case "oneof":
+			allowed := strings.Fields(arg)
+			if !slices.Contains(allowed, fmt.Sprint(v.Interface())) {
+				return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+			}
+		default:
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+	return nil
+}
+
+func hasLength(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// compareValues returns the numbers to compare for a min or max rule: the
+// value and the bound, or the length and the argument for strings, slices and
+// maps.
+func compareValues(v reflect.Value, arg string) (float64, float64, error) {
+	if hasLength(v) {
+		limit, err := strconv.Atoi(arg)
+		return float64(v.Len()), float64(limit), err
+	}
+
+	bound := reflect.New(v.Type()).Elem()
+	var err error
+	switch v.Type() {
+	case durationType:
+		err = decodeDuration(arg, bound)
+	case byteSizeType:
+		err = decodeByteSize(arg, bound)
+	default:
+		err = decodeScalar(arg, bound)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	n, ok := toFloat(v.Interface())
+	limit, _ := toFloat(bound.Interface())
+	if !ok {
+		return 0, 0, fmt.Errorf("cannot compare %s", v.Type())
+	}
+	return n, limit, nil
+}
+
+// ByteSize is a number of bytes, decoded from strings such as "512", "64KB"
+// or "1.5GiB". Units are powers of 1024.
+type ByteSize int64
+
+var byteSizeType = reflect.TypeOf(ByteSize(0))
+
+var byteSizeUnits = map[string]float64{
+	"":  1,
+	"B": 1,
+	"K": 1 << 10, "KB": 1 << 10, "KIB": 1 << 10,
+	"M": 1 << 20, "MB": 1 << 20, "MIB": 1 << 20,
+	"G": 1 << 30, "GB": 1 << 30, "GIB": 1 << 30,
+	"T": 1 << 40, "TB": 1 << 40, "TIB": 1 << 40,
+}
+
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := byteSizeUnits[strings.ToUpper(strings.TrimSpace(s[i:]))]
+	if err != nil || !ok {
+		return 0, errors.New("invalid size " + strconv.Quote(s))
+	}
+	size := n * unit
+	if size >= math.MaxInt64 {
+		return 0, errors.New("size out of range " + strconv.Quote(s))
+	}
+	return ByteSize(size), nil
+}
@@ -0,0 +1,131 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type paramsTestSuit struct {
+	suite.Suite
+}
+
+func TestParams(t *testing.T) {
+	suite.Run(t, new(paramsTestSuit))
+}
+
+type serverParams struct {
+	Addr    string        `param:"addr,required"`
+	Port    int           `param:"port" default:"8080" validate:"min=1,max=65535"`
+	Timeout time.Duration `param:"timeout" default:"5s" validate:"min=1s"`
+	Buffer  ByteSize      `param:"buffer" default:"4KiB"`
+	Debug   bool          `param:"debug"`
+	Mode    string        `param:"mode" default:"fast" validate:"oneof=fast safe"`
+	Hosts   []string      `param:"hosts" validate:"max=3"`
+	Ratio   float32
+	TLS     struct {
+		Cert string `param:"cert,required"`
+	} `param:"tls"`
+	Labels map[string]int `param:"labels"`
+	Extra  any            `param:"extra"`
+	Ignore string         `param:"-"`
+}
+
+func (t *paramsTestSuit) TestDecode() {
+	var p serverParams
+	err := DecodeParams(map[string]any{
+		"addr":    "localhost",
+		"port":    float64(9090),
+		"timeout": "1m",
+		"buffer":  "1.5MB",
+		"debug":   "true",
+		"hosts":   "a, b",
+		"RATIO":   1,
+		"tls":     map[string]any{"cert": "cert.pem"},
+		"labels":  map[string]any{"a": "1", "b": 2},
+		"extra":   []any{1},
+		"ignore":  "x",
+	}, &p)
+	t.Assertions.NoError(err, "DecodeParams() should not return error")
+
+	t.Assertions.Equal("localhost", p.Addr)
+	t.Assertions.Equal(9090, p.Port)
+	t.Assertions.Equal(time.Minute, p.Timeout)
+	t.Assertions.Equal(ByteSize(1536<<10), p.Buffer)
+	t.Assertions.True(p.Debug)
+	t.Assertions.Equal("fast", p.Mode)
+	t.Assertions.Equal([]string{"a", "b"}, p.Hosts)
+	t.Assertions.Equal(float32(1), p.Ratio)
+	t.Assertions.Equal("cert.pem", p.TLS.Cert)
+	t.Assertions.Equal(map[string]int{"a": 1, "b": 2}, p.Labels)
+	t.Assertions.Equal([]any{1}, p.Extra)
+	t.Assertions.Empty(p.Ignore)
+}
+
+func (t *paramsTestSuit) TestDecodeDefaults() {
+	var p serverParams
+	err := DecodeParams(map[string]any{"addr": "localhost", "tls": map[string]any{"cert": "c"}}, &p)
+	t.Assertions.NoError(err, "DecodeParams() should not return error")
+	t.Assertions.Equal(8080, p.Port)
+	t.Assertions.Equal(5*time.Second, p.Timeout)
+	t.Assertions.Equal(ByteSize(4096), p.Buffer)
+}
+
+func (t *paramsTestSuit) TestDecodeErrors() {
+	cases := map[string]struct {
+		params map[string]any
+		err    string
+	}{
+		"required":     {map[string]any{}, "param addr: required"},
+		"nested":       {map[string]any{"addr": "a", "tls": map[string]any{}}, "param tls.cert: required"},
+		"not integral": {map[string]any{"addr": "a", "port": 1.5}, "param port: 1.5 does not fit in int"},
+		"invalid int":  {map[string]any{"addr": "a", "port": "http"}, `param port: invalid int "http"`},
+		"max":          {map[string]any{"addr": "a", "port": 70000}, "param port: must be at most 65535"},
+		"min duration": {map[string]any{"addr": "a", "timeout": "10ms"}, "param timeout: must be at least 1s"},
+		"duration":     {map[string]any{"addr": "a", "timeout": 10}, "param timeout: expected duration string, got int"},
+		"size":         {map[string]any{"addr": "a", "buffer": "10XB"}, `param buffer: invalid size "10XB"`},
+		"bool":         {map[string]any{"addr": "a", "debug": "maybe"}, `param debug: invalid bool "maybe"`},
+		"oneof":        {map[string]any{"addr": "a", "mode": "slow"}, "param mode: must be one of fast, safe"},
+		"slice length": {map[string]any{"addr": "a", "hosts": []any{"a", "b", "c", "d"}}, "param hosts: must be at most 3"},
+		"map values":   {map[string]any{"addr": "a", "labels": map[string]any{"a": "x"}}, `param labels.a: invalid int "x"`},
+	}
+	for name, c := range cases {
+		t.Run(name, func() {
+			var p serverParams
+			t.Assertions.EqualError(DecodeParams(c.params, &p), c.err)
+		})
+	}
+}
+
+func (t *paramsTestSuit) TestParamsConstructor() {
+	c := New()
+	constructor := Params(func(depends map[string]any, p serverParams) (interface{}, error) {
+		return p.Addr, nil
+	})
+	t.Assertions.NoError(c.Register(BeanInfo{Name: "server", Constructor: constructor}))
+
+	_, err := c.Get("server")
+	t.Assertions.EqualError(err, "create server: param addr: required")
+}
+
+func (t *paramsTestSuit) TestParseByteSize() {
+	cases := map[string]ByteSize{
+		"512":    512,
+		"1k":     1024,
+		"64KB":   64 << 10,
+		"2 MiB":  2 << 20,
+		"1.5GB":  3 << 29,
+		"1TB":    1 << 40,
+		"  10B ": 10,
+	}
+	for s, want := range cases {
+		t.Run(s, func() {
+			got, err := ParseByteSize(s)
+			t.Assertions.NoError(err)
+			t.Assertions.Equal(want, got)
+		})
+	}
+	_, err := ParseByteSize("MB")
+	t.Assertions.Error(err)
+}
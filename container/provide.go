@@ -0,0 +1,192 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+type ProvideOptions func(*BeanInfo)
+
+// WithName registers the provided bean under name instead of its type name.
+func WithName(name string) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Name = name
+	}
+}
+
+func WithScope(scope Scope) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Scope = scope
+	}
+}
+
+func WithConditions(conditions ...Condition) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Conditions = append(b.Conditions, conditions...)
+	}
+}
+
+// WithInjectFields fills the `inject` tagged fields of the provided bean.
+func WithInjectFields() ProvideOptions {
+	return func(b *BeanInfo) {
+		b.InjectFields = true
+	}
+}
+
+// WithGroups adds the provided bean to groups, see GetGroup.
+func WithGroups(groups ...string) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Groups = append(b.Groups, groups...)
+	}
+}
+
+func WithPriority(priority int) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Priority = priority
+	}
+}
+
+// WithPrimary makes the provided bean win when several beans match a Resolve.
+func WithPrimary() ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Primary = true
+	}
+}
+
+func WithRetry(policy RetryPolicy) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.Retry = policy
+	}
+}
+
+// WithNonCritical marks the provided bean non-critical for health checks.
+func WithNonCritical() ProvideOptions {
+	return func(b *BeanInfo) {
+		b.NonCritical = true
+	}
+}
+
+func WithHealthTimeout(timeout time.Duration) ProvideOptions {
+	return func(b *BeanInfo) {
+		b.HealthTimeout = timeout
+	}
+}
+
+// Provide registers a bean built by an ordinary function such as
+// func(*DB, *Cache) (*Service, error). Each parameter is resolved by type
+// from the registered beans, the first result is the bean and an optional
+// trailing error result reports a construction failure. A leading
+// context.Context parameter receives the context of GetContext, and a
+// func() (T, error) parameter, such as a Provider[T], is a lazy dependency on
+// T. The bean is named after its type unless WithName is given.
+func (c *Container) Provide(constructor any, options ...ProvideOptions) error {
+	beanInfo, err := provideInfo(constructor)
+	if err != nil {
+		return err
+	}
+	for _, o := range options {
+		o(&beanInfo)
+	}
+	return c.Register(beanInfo)
+}
+
+func provideInfo(constructor any) (BeanInfo, error) {
+	fn := reflect.ValueOf(constructor)
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+		return BeanInfo{}, fmt.Errorf("constructor must be a function, got %T", constructor)
+	}
+	t := fn.Type()
+	if t.IsVariadic() {
+		return BeanInfo{}, fmt.Errorf("constructor must not be variadic: %s", t)
+	}
+	switch {
+	case t.NumOut() == 1:
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+	default:
+		return BeanInfo{}, fmt.Errorf("constructor must return (T) or (T, error): %s", t)
+	}
+
+	// ctxArgs is 1 when the first parameter is a context.Context
+	ctxArgs := 0
+	if t.NumIn() > 0 && t.In(0) == contextType {
+		ctxArgs = 1
+	}
+	// paramNames holds the bean name of each parameter after the context;
+	// lazy ones are func() (T, error) parameters, resolved through a Provider.
+	paramNames := make([]string, t.NumIn()-ctxArgs)
+	lazy := make([]bool, len(paramNames))
+	var deps, lazyDeps []string
+	for i := range paramNames {
+		in := t.In(i + ctxArgs)
+		if isProviderType(in) {
+			paramNames[i] = typeName(in.Out(0))
+			lazy[i] = true
+			lazyDeps = append(lazyDeps, paramNames[i])
+			continue
+		}
+		paramNames[i] = typeName(in)
+		deps = append(deps, paramNames[i])
+	}
+
+	return BeanInfo{
+		Name:             typeName(t.Out(0)),
+		Dependencies:     deps,
+		LazyDependencies: lazyDeps,
+		Type:             t.Out(0),
+		ContextConstructor: func(ctx context.Context, depends map[string]any, params map[string]any) (interface{}, error) {
+			args := make([]reflect.Value, 0, t.NumIn())
+			if ctxArgs == 1 {
+				args = append(args, reflect.ValueOf(&ctx).Elem())
+			}
+			for i, dep := range paramNames {
+				in := t.In(i + ctxArgs)
+				if lazy[i] {
+					args = append(args, providerValue(depends[dep].(Provider[any]), in))
+					continue
+				}
+				arg, err := argValue(depends[dep], in)
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+			}
+			results := fn.Call(args)
+			if len(results) == 2 && !results[1].IsNil() {
+				return nil, results[1].Interface().(error)
+			}
+			return results[0].Interface(), nil
+		},
+	}, nil
+}
+
+func argValue(v any, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(t), nil
+	}
+	value := reflect.ValueOf(v)
+	if !value.Type().AssignableTo(t) {
+		return reflect.Value{}, &Error{Kind: ErrTypeMismatch, Expected: t, Actual: value.Type()}
+	}
+	return value, nil
+}
+
+// typeName is the name under which a type is provided. Unlike
+// reflect.Type.String it uses the full package path.
+func typeName(t reflect.Type) string {
+	prefix := ""
+	for t.Kind() == reflect.Ptr {
+		prefix += "*"
+		t = t.Elem()
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return prefix + t.PkgPath() + "." + t.Name()
+	}
+	return prefix + t.String()
+}
@@ -0,0 +1,122 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type provideTestSuit struct {
+	suite.Suite
+}
+
+func TestProvide(t *testing.T) {
+	suite.Run(t, new(provideTestSuit))
+}
+
+type db struct{ dsn string }
+type cache struct{ db *db }
+type service struct {
+	db    *db
+	cache *cache
+}
+
+func newDB() *db {
+	return &db{dsn: "memory"}
+}
+
+func newCache(d *db) (*cache, error) {
+	return &cache{db: d}, nil
+}
+
+func newService(d *db, c *cache) (*service, error) {
+	return &service{db: d, cache: c}, nil
+}
+
+func (t *provideTestSuit) TestProvide() {
+	c := New()
+	t.Assertions.NoError(c.Provide(newService), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newCache), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newDB), "Provide() should not return error")
+
+	s, err := Get[*service](c, typeName(reflect.TypeOf(&service{})))
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NotNil(s.db, "db should be injected")
+	t.Assertions.Same(s.db, s.cache.db, "db should be shared")
+}
+
+func (t *provideTestSuit) TestProvideWithName() {
+	c := New()
+	t.Assertions.NoError(c.Provide(newDB, WithName("db")), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newCache, WithName("cache")), "Provide() should not return error")
+
+	cc, err := Get[*cache](c, "cache")
+	t.Assertions.NoError(err, "Get() should not return error")
+	d, err := Get[*db](c, "db")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Same(d, cc.db, "db should be resolved by type")
+}
+
+func (t *provideTestSuit) TestProvideWithRegister() {
+	c := New()
+	err := c.Register(BeanInfo{
+		Name: "db",
+		Type: reflect.TypeOf(&db{}),
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &db{dsn: params["dsn"].(string)}, nil
+		},
+		Params: map[string]any{"dsn": "file"},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+	t.Assertions.NoError(c.Provide(newCache), "Provide() should not return error")
+
+	cc, err := Get[*cache](c, typeName(reflect.TypeOf(&cache{})))
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("file", cc.db.dsn, "db should come from Register")
+}
+
+func (t *provideTestSuit) TestProvideError() {
+	c := New()
+	errFailed := errors.New("failed")
+	t.Assertions.NoError(c.Provide(func() (*db, error) { return nil, errFailed }), "Provide() should not return error")
+
+	_, err := c.Get(typeName(reflect.TypeOf(&db{})))
+	t.Assertions.ErrorIs(err, errFailed, "Get() should return constructor error")
+}
+
+func (t *provideTestSuit) TestProvideMissingDependency() {
+	c := New()
+	t.Assertions.NoError(c.Provide(newCache), "Provide() should not return error")
+
+	_, err := c.Get(typeName(reflect.TypeOf(&cache{})))
+	t.Assertions.ErrorContains(err, "bean not found: *github.com/0x0001/halo/container.db")
+}
+
+func (t *provideTestSuit) TestProvideAmbiguous() {
+	c := New()
+	t.Assertions.NoError(c.Provide(newDB, WithName("db1")), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newDB, WithName("db2")), "Provide() should not return error")
+	t.Assertions.NoError(c.Provide(newCache), "Provide() should not return error")
+
+	_, err := c.Get(typeName(reflect.TypeOf(&cache{})))
+	t.Assertions.ErrorContains(err, "ambiguous bean")
+}
+
+func (t *provideTestSuit) TestProvideInvalid() {
+	c := New()
+	cases := map[string]any{
+		"nil":          nil,
+		"not func":     1,
+		"no result":    func() {},
+		"bad error":    func() (*db, int) { return nil, 0 },
+		"many results": func() (*db, *cache, error) { return nil, nil, nil },
+		"variadic":     func(...*db) *cache { return nil },
+	}
+	for name, constructor := range cases {
+		t.Run(name, func() {
+			t.Assertions.Error(c.Provide(constructor), "Provide() should return error")
+		})
+	}
+}
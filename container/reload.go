@@ -0,0 +1,132 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Watcher is told when the instance of a bean is swapped after Reload or
+// Unregister, with new nil for an unregistered bean.
+type Watcher func(name string, old, new any)
+
+type ReloadOption struct {
+	// Rebuild creates the reloaded beans right away instead of on the next
+	// Get.
+	Rebuild bool
+}
+
+type ReloadOptions func(*ReloadOption)
+
+func WithRebuild() ReloadOptions {
+	return func(o *ReloadOption) {
+		o.Rebuild = true
+	}
+}
+
+// Watch adds a watcher of the bean name created by c or its children.
+func (c *Container) Watch(name string, watcher Watcher) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watchers == nil {
+		c.watchers = make(map[string][]Watcher)
+	}
+	c.watchers[name] = append(c.watchers[name], watcher)
+}
+
+// Reload drops the bean name and every bean depending on it, directly or
+// transitively, from the caches of the container holding it and of its
+// children, and closes them, dependents first. They are built again on the
+// next Get, or right away with WithRebuild, and their watchers are told of
+// the new instances.
+func (c *Container) Reload(ctx context.Context, name string, opts ...ReloadOptions) error {
+	var o ReloadOption
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c.mu.Lock()
+	owner, beanInfo, err := c.find(name)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	dropped := c.holder(owner, beanInfo).drop(beanInfo.Name)
+	c.mu.Unlock()
+
+	errs := []error{closeDropped(ctx, dropped)}
+	if o.Rebuild {
+		for _, d := range dropped {
+			if _, err := d.c.GetContext(ctx, d.name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Unregister removes the definitions of the bean name from c, and closes
+// and drops it and its dependents like Reload. Dependents are built again
+// on the next Get, without it.
+func (c *Container) Unregister(ctx context.Context, name string) error {
+	c.mu.Lock()
+	if _, ok := c.infos[name]; !ok {
+		c.mu.Unlock()
+		return &Error{Kind: ErrNotFound, Name: name}
+	}
+	dropped := c.drop(name)
+	delete(c.replaced, name)
+	c.undefine(name)
+	notify := func() {}
+	for _, d := range dropped {
+		if d.c == c && d.name == name {
+			notify = c.notifier(name, d.bean, nil)
+		}
+	}
+	c.mu.Unlock()
+
+	notify()
+	return closeDropped(ctx, dropped)
+}
+
+// drop invalidates name in c and returns the dropped beans, see invalidate.
+// They are kept to be passed to their watchers once rebuilt. Must be called
+// with c.mu held.
+func (c *Container) drop(name string) []cached {
+	dropped := c.invalidate(name)
+	for _, d := range dropped {
+		if d.c.replaced == nil {
+			d.c.replaced = make(map[string]any)
+		}
+		if _, ok := d.c.replaced[d.name]; !ok {
+			d.c.replaced[d.name] = d.bean
+		}
+	}
+	return dropped
+}
+
+// closeDropped releases the dropped beans, last first.
+func closeDropped(ctx context.Context, dropped []cached) error {
+	var errs []error
+	for _, d := range slices.Backward(dropped) {
+		if err := closeBean(ctx, d.bean); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", d.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifier returns a function telling the watchers of name in c and its
+// parents that old was swapped for new. Must be called with c.mu held.
+func (c *Container) notifier(name string, old, new any) func() {
+	var watchers []Watcher
+	for x := c; x != nil; x = x.parent {
+		watchers = append(slices.Clone(x.watchers[name]), watchers...)
+	}
+	return func() {
+		for _, watcher := range watchers {
+			watcher(name, old, new)
+		}
+	}
+}
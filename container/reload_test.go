@@ -0,0 +1,182 @@
+package container
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type reloadTestSuit struct {
+	suite.Suite
+}
+
+func TestReload(t *testing.T) {
+	suite.Run(t, new(reloadTestSuit))
+}
+
+// conn is a closable bean recording its generation.
+type conn struct {
+	name   string
+	gen    int
+	closed bool
+}
+
+func (c *conn) Close() error {
+	c.closed = true
+	return nil
+}
+
+type swap struct {
+	name     string
+	old, new any
+}
+
+func (t *reloadTestSuit) newContainer() (*Container, *[]swap) {
+	c := New()
+	var mu sync.Mutex
+	gens := make(map[string]int)
+	register := func(name string, beanInfo BeanInfo) {
+		beanInfo.Name = name
+		beanInfo.Constructor = func(depends map[string]any, params map[string]any) (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			gens[name]++
+			return &conn{name: name, gen: gens[name]}, nil
+		}
+		t.Assertions.NoError(c.Register(beanInfo))
+	}
+	register("config", BeanInfo{})
+	register("db", BeanInfo{Dependencies: []string{"config"}})
+	register("repo", BeanInfo{Dependencies: []string{"db"}})
+	register("cache", BeanInfo{OptionalDependencies: []string{"config"}})
+	register("other", BeanInfo{})
+
+	swaps := new([]swap)
+	for _, name := range []string{"config", "db", "repo", "cache"} {
+		c.Watch(name, func(name string, old, new any) {
+			*swaps = append(*swaps, swap{name, old, new})
+		})
+	}
+	t.Assertions.NoError(c.InitAll(context.Background(), 1))
+	return c, swaps
+}
+
+func (t *reloadTestSuit) get(c *Container, name string) *conn {
+	bean, err := Get[*conn](c, name)
+	t.Assertions.NoError(err, "Get() should not return error")
+	return bean
+}
+
+func (t *reloadTestSuit) TestReload() {
+	c, swaps := t.newContainer()
+	db, repo, other := t.get(c, "db"), t.get(c, "repo"), t.get(c, "other")
+
+	t.Assertions.NoError(c.Reload(context.Background(), "db"))
+	t.Assertions.True(db.closed, "the reloaded bean should be closed")
+	t.Assertions.True(repo.closed, "dependents should be closed")
+	t.Assertions.False(t.get(c, "config").closed, "dependencies should be kept")
+	t.Assertions.Same(other, t.get(c, "other"))
+	t.Assertions.Empty(*swaps, "watchers should be told once the bean is rebuilt")
+
+	repo2 := t.get(c, "repo")
+	t.Assertions.Equal(2, repo2.gen)
+	t.Assertions.Equal([]swap{
+		{"db", db, t.get(c, "db")},
+		{"repo", repo, repo2},
+	}, *swaps)
+}
+
+func (t *reloadTestSuit) TestRebuild() {
+	c, swaps := t.newContainer()
+	config, db, repo, cache := t.get(c, "config"), t.get(c, "db"), t.get(c, "repo"), t.get(c, "cache")
+
+	t.Assertions.NoError(c.Reload(context.Background(), "config", WithRebuild()))
+	t.Assertions.Equal([]swap{
+		{"config", config, t.get(c, "config")},
+		{"cache", cache, t.get(c, "cache")},
+		{"db", db, t.get(c, "db")},
+		{"repo", repo, t.get(c, "repo")},
+	}, *swaps, "dependents should be rebuilt right away in creation order")
+	t.Assertions.Equal(2, t.get(c, "repo").gen)
+}
+
+func (t *reloadTestSuit) TestUnregister() {
+	c, swaps := t.newContainer()
+	config, cache := t.get(c, "config"), t.get(c, "cache")
+
+	t.Assertions.NoError(c.Unregister(context.Background(), "config"))
+	t.Assertions.True(config.closed, "the unregistered bean should be closed")
+	t.Assertions.True(cache.closed, "dependents should be closed")
+	t.Assertions.Equal([]swap{{"config", config, nil}}, *swaps)
+
+	cache2 := t.get(c, "cache")
+	t.Assertions.Equal(2, cache2.gen, "dependents should be rebuilt without the bean")
+	t.Assertions.Equal(swap{"cache", cache, cache2}, (*swaps)[1])
+	_, err := c.Get("db")
+	t.Assertions.ErrorIs(err, ErrNotFound)
+
+	t.Assertions.ErrorIs(c.Unregister(context.Background(), "config"), ErrNotFound)
+}
+
+func (t *reloadTestSuit) TestChild() {
+	c, _ := t.newContainer()
+	child := c.NewChild()
+	db := t.get(child, "db")
+
+	var swapped []string
+	child.Watch("db", func(name string, old, new any) { swapped = append(swapped, name) })
+	t.Assertions.NoError(child.Reload(context.Background(), "db", WithRebuild()))
+	t.Assertions.True(db.closed, "singletons should be reloaded in the container defining them")
+	t.Assertions.Equal(2, t.get(c, "db").gen)
+	t.Assertions.Empty(swapped, "watchers of children should not be told of beans of the parent")
+
+	t.Assertions.ErrorIs(child.Unregister(context.Background(), "db"), ErrNotFound, "children should not unregister beans of the parent")
+	t.Assertions.ErrorIs(child.Reload(context.Background(), "missing"), ErrNotFound)
+}
+
+func (t *reloadTestSuit) TestReloadThroughPrototype() {
+	c, _ := t.newContainer()
+	t.Assertions.NoError(c.Override(BeanInfo{
+		Name:         "db",
+		Scope:        ScopePrototype,
+		Dependencies: []string{"config"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &conn{name: "db"}, nil
+		},
+	}))
+	repo := t.get(c, "repo")
+
+	t.Assertions.NoError(c.Reload(context.Background(), "config"))
+	t.Assertions.True(repo.closed, "dependents reached through prototypes should be closed")
+	t.Assertions.NotSame(repo, t.get(c, "repo"), "dependents reached through prototypes should be rebuilt")
+}
+
+func (t *reloadTestSuit) TestReloadChildDependents() {
+	c, _ := t.newContainer()
+	child := c.NewChild()
+	t.Assertions.NoError(child.Register(BeanInfo{
+		Name:         "session",
+		Scope:        ScopeScoped,
+		Dependencies: []string{"db"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &conn{name: "session", gen: depends["db"].(*conn).gen}, nil
+		},
+	}))
+	session := t.get(child, "session")
+	var swapped []swap
+	child.Watch("session", func(name string, old, new any) {
+		swapped = append(swapped, swap{name, old, new})
+	})
+
+	t.Assertions.NoError(c.Reload(context.Background(), "db", WithRebuild()))
+	t.Assertions.True(session.closed, "dependents cached in children should be closed")
+	t.Assertions.Len(swapped, 1, "dependents in children should be rebuilt right away")
+	session2 := t.get(child, "session")
+	t.Assertions.Equal(2, session2.gen, "dependents cached in children should use the reloaded bean")
+	t.Assertions.Equal([]swap{{"session", session, session2}}, swapped)
+
+	t.Assertions.NoError(child.Close(context.Background()))
+	t.Assertions.NotContains(c.children, child, "closed children should not be tracked")
+}
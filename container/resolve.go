@@ -0,0 +1,52 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Resolve returns the bean whose Type is assignable to T, such as the single
+// implementation of an interface. When several beans match, the one marked
+// Primary is used. Beans registered without a Type never match.
+func Resolve[T any](container *Container) (T, error) {
+	var t T
+	name, err := container.resolve(reflect.TypeFor[T]())
+	if err != nil {
+		return t, err
+	}
+	return Get[T](container, name)
+}
+
+// resolve returns the name of the bean visible from c whose Type is
+// assignable to t.
+func (c *Container) resolve(t reflect.Type) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var names, candidates, primaries []string
+	for _, name := range c.names() {
+		beanInfo, err := c.lookup(name)
+		if err != nil || beanInfo.Type == nil || !beanInfo.Type.AssignableTo(t) {
+			continue
+		}
+		names = append(names, name)
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", name, beanInfo.Type))
+		if beanInfo.Primary {
+			primaries = append(primaries, name)
+		}
+	}
+
+	switch {
+	case len(names) == 0:
+		return "", &Error{Kind: ErrNotFound, Expected: t, msg: "no bean assignable to " + t.String()}
+	case len(names) == 1:
+		return names[0], nil
+	case len(primaries) == 1:
+		return primaries[0], nil
+	case len(primaries) > 1:
+		return "", &Error{Kind: ErrAmbiguous, Expected: t, Candidates: primaries, msg: fmt.Sprintf("ambiguous bean for %s: several primary beans: %s", t, strings.Join(primaries, ", "))}
+	default:
+		return "", &Error{Kind: ErrAmbiguous, Expected: t, Candidates: names, msg: fmt.Sprintf("ambiguous bean for %s: %s; mark one as Primary", t, strings.Join(candidates, ", "))}
+	}
+}
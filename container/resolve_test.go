@@ -0,0 +1,88 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type resolveTestSuit struct {
+	suite.Suite
+}
+
+func TestResolve(t *testing.T) {
+	suite.Run(t, new(resolveTestSuit))
+}
+
+type store interface {
+	Load(key string) string
+}
+
+type memStore struct{}
+
+func (memStore) Load(key string) string { return "mem:" + key }
+
+type diskStore struct{}
+
+func (*diskStore) Load(key string) string { return "disk:" + key }
+
+func (t *resolveTestSuit) TestResolve() {
+	c := New()
+	_, err := Resolve[store](c)
+	t.Assertions.EqualError(err, "no bean assignable to container.store")
+
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }))
+	s, err := Resolve[store](c)
+	t.Assertions.NoError(err, "Resolve() should not return error")
+	t.Assertions.Equal("mem:a", s.Load("a"))
+
+	m, err := Resolve[memStore](c)
+	t.Assertions.NoError(err, "Resolve() should resolve concrete types")
+	t.Assertions.Equal(memStore{}, m)
+
+	t.Assertions.NoError(c.Provide(func() *diskStore { return &diskStore{} }))
+	_, err = Resolve[store](c)
+	t.Assertions.EqualError(err, "ambiguous bean for container.store: "+
+		"*github.com/0x0001/halo/container.diskStore (*container.diskStore), "+
+		"github.com/0x0001/halo/container.memStore (container.memStore); mark one as Primary")
+}
+
+func (t *resolveTestSuit) TestPrimary() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }, WithName("mem")))
+	t.Assertions.NoError(c.Provide(func() *diskStore { return &diskStore{} }, WithName("disk"), WithPrimary()))
+
+	s, err := Resolve[store](c)
+	t.Assertions.NoError(err, "Resolve() should not return error")
+	t.Assertions.Equal("disk:a", s.Load("a"))
+
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:    "other",
+		Type:    reflect.TypeFor[memStore](),
+		Primary: true,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return memStore{}, nil
+		},
+	}))
+	_, err = Resolve[store](c)
+	t.Assertions.EqualError(err, "ambiguous bean for container.store: several primary beans: disk, other")
+}
+
+func (t *resolveTestSuit) TestChild() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }, WithName("store")))
+	child := c.NewChild()
+	t.Assertions.NoError(child.Provide(func() *diskStore { return &diskStore{} }, WithName("store")))
+
+	s, err := Resolve[store](child)
+	t.Assertions.NoError(err, "beans shadowed by the child should not be candidates")
+	t.Assertions.Equal("disk:a", s.Load("a"))
+}
+
+func (t *resolveTestSuit) TestTypeMismatch() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() memStore { return memStore{} }, WithName("store")))
+	_, err := Get[*diskStore](c, "store")
+	t.Assertions.EqualError(err, "bean store: type mismatch: expected *container.diskStore, got container.memStore")
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy retries a failing constructor with exponential backoff.
+type RetryPolicy struct {
+	// MaxAttempts counts the calls to the constructor, the first included.
+	// Retries are disabled if it is less than 2.
+	MaxAttempts int
+	// InitialBackoff is the wait after the first failure, multiplied by
+	// Multiplier (2 if unset) after each further failure, up to MaxBackoff
+	// if positive.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction of each wait that is randomized, from 0 to 1,
+	// so that beans failing together do not retry together.
+	Jitter float64
+	// Retryable reports whether a constructor error is worth retrying. Every
+	// error is if nil.
+	Retryable func(err error) bool
+}
+
+// backoff returns the wait after the given failed attempt, starting at 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= multiplier
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 {
+		d = min(d, float64(p.MaxBackoff))
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// retry calls construct until it succeeds, as allowed by p. The error of
+// every attempt is reported when they all fail.
+func (p RetryPolicy) retry(ctx context.Context, construct func() (any, error)) (any, error) {
+	if p.MaxAttempts < 2 {
+		return construct()
+	}
+
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		bean, err := construct()
+		if err == nil {
+			return bean, nil
+		}
+		errs = append(errs, fmt.Errorf("attempt %d: %w", attempt, err))
+		if attempt == p.MaxAttempts || (p.Retryable != nil && !p.Retryable(err)) {
+			return nil, errors.Join(errs...)
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			errs = append(errs, fmt.Errorf("retry: %w", ctx.Err()))
+			return nil, errors.Join(errs...)
+		}
+	}
+}
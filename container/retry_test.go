@@ -0,0 +1,107 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type retryTestSuit struct {
+	suite.Suite
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, new(retryTestSuit))
+}
+
+// flaky registers a bean whose constructor fails the given number of times.
+func (t *retryTestSuit) flaky(c *Container, failures int32, retry RetryPolicy) *atomic.Int32 {
+	calls := new(atomic.Int32)
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:  "db",
+		Retry: retry,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			if n := calls.Add(1); n <= failures {
+				return nil, errors.New("connection refused")
+			}
+			return "db", nil
+		},
+	}))
+	return calls
+}
+
+func (t *retryTestSuit) TestRetry() {
+	c := New()
+	calls := t.flaky(c, 2, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	db, err := Get[string](c, "db")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal("db", db)
+	t.Assertions.EqualValues(3, calls.Load())
+}
+
+func (t *retryTestSuit) TestAttempts() {
+	c := New()
+	calls := t.flaky(c, 5, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := c.Get("db")
+	t.Assertions.EqualError(err, "create db: attempt 1: connection refused\nattempt 2: connection refused\nattempt 3: connection refused")
+	t.Assertions.ErrorIs(err, ErrConstruct)
+	t.Assertions.EqualValues(3, calls.Load())
+}
+
+func (t *retryTestSuit) TestRetryable() {
+	c := New()
+	calls := t.flaky(c, 5, RetryPolicy{
+		MaxAttempts: 3,
+		Retryable:   func(err error) bool { return err.Error() != "connection refused" },
+	})
+
+	_, err := c.Get("db")
+	t.Assertions.EqualError(err, "create db: attempt 1: connection refused")
+	t.Assertions.EqualValues(1, calls.Load(), "errors not retryable should not be retried")
+}
+
+func (t *retryTestSuit) TestContext() {
+	c := New()
+	calls := t.flaky(c, 5, RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.GetContext(ctx, "db")
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded)
+	t.Assertions.ErrorContains(err, "attempt 1: connection refused\nretry: context deadline exceeded")
+	t.Assertions.EqualValues(1, calls.Load())
+}
+
+func (t *retryTestSuit) TestNoRetry() {
+	c := New()
+	calls := t.flaky(c, 1, RetryPolicy{})
+
+	_, err := c.Get("db")
+	t.Assertions.EqualError(err, "create db: connection refused")
+	t.Assertions.EqualValues(1, calls.Load())
+}
+
+func (t *retryTestSuit) TestBackoff() {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	t.Assertions.Equal(100*time.Millisecond, p.backoff(1))
+	t.Assertions.Equal(200*time.Millisecond, p.backoff(2))
+	t.Assertions.Equal(800*time.Millisecond, p.backoff(4))
+	t.Assertions.Equal(time.Second, p.backoff(5))
+	t.Assertions.Equal(time.Second, p.backoff(100))
+
+	p.Multiplier = 3
+	t.Assertions.Equal(900*time.Millisecond, p.backoff(3))
+
+	p.Jitter = 0.5
+	for range 100 {
+		d := p.backoff(2)
+		t.Assertions.Greater(d, 150*time.Millisecond)
+		t.Assertions.LessOrEqual(d, 300*time.Millisecond)
+	}
+}
@@ -0,0 +1,106 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// Starter is implemented by beans that need to be started once every bean
+// is built, such as servers. Start must not block.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+type RunOption struct {
+	// Parallelism is passed to InitAll.
+	Parallelism int
+	// ShutdownTimeout bounds the time spent closing the beans.
+	ShutdownTimeout time.Duration
+	// Signals stop the application. None are handled if empty.
+	Signals []os.Signal
+}
+
+type RunOptions func(*RunOption)
+
+func WithParallelism(parallelism int) RunOptions {
+	return func(o *RunOption) {
+		o.Parallelism = parallelism
+	}
+}
+
+func WithShutdownTimeout(timeout time.Duration) RunOptions {
+	return func(o *RunOption) {
+		o.ShutdownTimeout = timeout
+	}
+}
+
+func WithSignals(signals ...os.Signal) RunOptions {
+	return func(o *RunOption) {
+		o.Signals = signals
+	}
+}
+
+// Run runs the beans of c as an application: it validates c, builds every
+// bean, starts the beans created in c implementing Starter in creation
+// order, so each after its dependencies, and waits for ctx to be done or
+// for SIGINT or SIGTERM, see WithSignals. It then closes c within the
+// shutdown timeout, 30s by default, stopping the beans in reverse order.
+// Run returns nil if the application started and shut down cleanly.
+func Run(ctx context.Context, c *Container, opts ...RunOptions) error {
+	o := RunOption{
+		ShutdownTimeout: 30 * time.Second,
+		Signals:         []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	stop := func() {}
+	if len(o.Signals) > 0 {
+		// NotifyContext without signals would stop on any signal
+		ctx, stop = signal.NotifyContext(ctx, o.Signals...)
+		defer stop()
+	}
+
+	err := c.Validate()
+	if err == nil {
+		err = c.InitAll(ctx, o.Parallelism)
+	}
+	if err == nil {
+		err = c.start(ctx)
+	}
+	if err == nil {
+		<-ctx.Done()
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(err, c.Close(shutdownCtx))
+}
+
+// start starts the created beans of c implementing Starter, in creation
+// order.
+func (c *Container) start(ctx context.Context) error {
+	c.mu.Lock()
+	order, beans := slices.Clone(c.order), maps.Clone(c.beans)
+	c.mu.Unlock()
+
+	for _, name := range order {
+		starter, ok := beans[name].(Starter)
+		if !ok {
+			continue
+		}
+		if err := starter.Start(ctx); err != nil {
+			return fmt.Errorf("start %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type runTestSuit struct {
+	suite.Suite
+}
+
+func TestRun(t *testing.T) {
+	suite.Run(t, new(runTestSuit))
+}
+
+// events records the Start and Stop calls of components.
+type events struct {
+	mu  sync.Mutex
+	log []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.log = append(e.log, event)
+}
+
+func (e *events) get() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.log...)
+}
+
+type component struct {
+	name     string
+	events   *events
+	startErr error
+	stopWait time.Duration
+}
+
+func (s *component) Start(ctx context.Context) error {
+	s.events.add("start " + s.name)
+	return s.startErr
+}
+
+func (s *component) Stop(ctx context.Context) error {
+	select {
+	case <-time.After(s.stopWait):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.events.add("stop " + s.name)
+	return nil
+}
+
+func (t *runTestSuit) newContainer(e *events, startErr error) *Container {
+	c := New()
+	register := func(name string, deps ...string) {
+		t.Assertions.NoError(c.Register(BeanInfo{
+			Name:         name,
+			Dependencies: deps,
+			Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+				s := &component{name: name, events: e}
+				if name == "server" {
+					s.startErr = startErr
+				}
+				return s, nil
+			},
+		}))
+	}
+	register("server", "repo")
+	register("repo", "db")
+	register("db")
+	return c
+}
+
+func (t *runTestSuit) TestRun() {
+	e := new(events)
+	c := t.newContainer(e, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, c)
+	}()
+
+	t.Assertions.Eventually(func() bool { return len(e.get()) == 3 }, time.Second, time.Millisecond)
+	t.Assertions.Equal([]string{"start db", "start repo", "start server"}, e.get(), "beans should start after their dependencies")
+	cancel()
+	t.Assertions.NoError(<-done, "Run() should not return error")
+	t.Assertions.Equal([]string{
+		"start db", "start repo", "start server",
+		"stop server", "stop repo", "stop db",
+	}, e.get(), "beans should stop in reverse order")
+}
+
+func (t *runTestSuit) TestSignal() {
+	e := new(events)
+	c := t.newContainer(e, nil)
+	done := make(chan error)
+	go func() {
+		done <- Run(context.Background(), c, WithSignals(os.Interrupt))
+	}()
+
+	t.Assertions.Eventually(func() bool { return len(e.get()) == 3 }, time.Second, time.Millisecond)
+	p, err := os.FindProcess(os.Getpid())
+	t.Assertions.NoError(err)
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.T().Skip("cannot send interrupt: ", err)
+	}
+	t.Assertions.NoError(<-done, "Run() should not return error")
+	t.Assertions.Len(e.get(), 6)
+}
+
+func (t *runTestSuit) TestStartError() {
+	e := new(events)
+	c := t.newContainer(e, errors.New("address in use"))
+
+	err := Run(context.Background(), c)
+	t.Assertions.EqualError(err, "start server: address in use")
+	t.Assertions.Equal([]string{
+		"start db", "start repo", "start server",
+		"stop server", "stop repo", "stop db",
+	}, e.get(), "created beans should be stopped")
+}
+
+func (t *runTestSuit) TestInvalid() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         "server",
+		Dependencies: []string{"db"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return nil, nil
+		},
+	}))
+	err := Run(context.Background(), c)
+	t.Assertions.ErrorIs(err, ErrNotFound, "Run() should validate the container")
+}
+
+func (t *runTestSuit) TestShutdownTimeout() {
+	c := New()
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name: "server",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &component{name: "server", events: new(events), stopWait: time.Hour}, nil
+		},
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Run(ctx, c, WithShutdownTimeout(10*time.Millisecond), WithParallelism(1))
+	t.Assertions.Less(time.Since(start), time.Second)
+	t.Assertions.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,30 @@
+//go:build unix
+
+package container
+
+import (
+	"context"
+	"syscall"
+	"time"
+)
+
+func (t *runTestSuit) TestNoSignals() {
+	e := new(events)
+	c := t.newContainer(e, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, c, WithSignals())
+	}()
+
+	t.Assertions.Eventually(func() bool { return len(e.get()) == 3 }, time.Second, time.Millisecond)
+	t.Assertions.NoError(syscall.Kill(syscall.Getpid(), syscall.SIGWINCH))
+	select {
+	case <-done:
+		t.Fail("Run() should not stop on signals without WithSignals")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	t.Assertions.NoError(<-done, "Run() should not return error")
+}
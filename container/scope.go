@@ -0,0 +1,51 @@
+package container
+
+import "fmt"
+
+// Scope controls how long a bean created by the container lives.
+type Scope int
+
+const (
+	// ScopeSingleton beans are created once and cached in the container
+	// defining them.
+	ScopeSingleton Scope = iota
+	// ScopePrototype beans are created anew on every Get.
+	ScopePrototype
+	// ScopeScoped beans are created once per scope, see Container.NewScope.
+	ScopeScoped
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeSingleton:
+		return "singleton"
+	case ScopePrototype:
+		return "prototype"
+	case ScopeScoped:
+		return "scoped"
+	default:
+		return fmt.Sprintf("Scope(%d)", int(s))
+	}
+}
+
+func (s *Scope) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "singleton":
+		*s = ScopeSingleton
+	case "prototype":
+		*s = ScopePrototype
+	case "scoped":
+		*s = ScopeScoped
+	default:
+		return fmt.Errorf("unknown scope: %q", text)
+	}
+	return nil
+}
+
+// NewScope returns a child container to resolve scoped beans from, see
+// NewChild. Scoped beans are cached in the container Get is called on, so
+// closing the scope releases them while singletons live on in the parent. A
+// scope must be closed once done with, or c keeps referencing it.
+func (c *Container) NewScope() *Container {
+	return c.NewChild()
+}
@@ -0,0 +1,121 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type scopeTestSuit struct {
+	suite.Suite
+}
+
+func TestScope(t *testing.T) {
+	suite.Run(t, new(scopeTestSuit))
+}
+
+func (t *scopeTestSuit) newContainer(scope Scope) *Container {
+	c := New()
+	err := c.Register(BeanInfo{
+		Name: "bean1",
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean1{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+	err = c.Register(BeanInfo{
+		Name:         "bean2",
+		Dependencies: []string{"bean1"},
+		Scope:        scope,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean2{
+				bean1: depends["bean1"].(*bean1),
+			}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+	return c
+}
+
+func (t *scopeTestSuit) TestPrototype() {
+	c := t.newContainer(ScopePrototype)
+
+	b1, err := Get[*bean2](c, "bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+	b2, err := Get[*bean2](c, "bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	t.Assertions.NotSame(b1, b2, "prototype should be created on every Get")
+	t.Assertions.Same(b1.bean1, b2.bean1, "singleton dependency should be shared")
+	t.Assertions.NotContains(c.beans, "bean2", "prototype should not be cached")
+}
+
+func (t *scopeTestSuit) TestScoped() {
+	c := t.newContainer(ScopeScoped)
+
+	_, err := c.Get("bean2")
+	t.Assertions.Error(err, "Get() should return error outside of a scope")
+
+	s1 := c.NewScope()
+	s2 := c.NewScope()
+	b1, err := Get[*bean2](s1, "bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+	b2, err := Get[*bean2](s1, "bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+	b3, err := Get[*bean2](s2, "bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+
+	t.Assertions.Same(b1, b2, "scoped bean should be cached in its scope")
+	t.Assertions.NotSame(b1, b3, "scoped bean should not be shared across scopes")
+	t.Assertions.Same(b1.bean1, b3.bean1, "singleton dependency should be shared")
+	t.Assertions.Contains(c.beans, "bean1", "singleton should be cached in the root")
+	t.Assertions.NotContains(s1.beans, "bean1", "singleton should not be cached in the scope")
+
+	t.Assertions.NoError(s1.Close(context.Background()), "Close() should not return error")
+	t.Assertions.Empty(s1.beans, "Close() should drop scoped beans")
+	t.Assertions.Contains(c.beans, "bean1", "Close() should keep singletons")
+}
+
+func (t *scopeTestSuit) TestScopeReleased() {
+	c := t.newContainer(ScopeScoped)
+
+	for i := 0; i < 1000; i++ {
+		s := c.NewScope()
+		_, err := s.Get("bean2")
+		t.Assertions.NoError(err, "Get() should not return error")
+		t.Assertions.NoError(s.Close(context.Background()), "Close() should not return error")
+	}
+	t.Assertions.Empty(c.children, "Close() should release the scopes")
+
+	child := c.NewChild()
+	s := child.NewScope()
+	_, err := s.Get("bean2")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.NoError(child.Close(context.Background()), "Close() should not return error")
+	t.Assertions.NoError(c.Replace("bean1", &bean1{}), "Replace() should not return error")
+	t.Assertions.NotContains(s.beans, "bean2", "Replace() should reach scopes of closed children")
+	t.Assertions.NoError(s.Close(context.Background()), "Close() should not return error")
+	t.Assertions.Empty(c.children, "Close() should release the scopes")
+}
+
+func (t *scopeTestSuit) TestSingletonDependsOnScoped() {
+	c := t.newContainer(ScopeScoped)
+	err := c.Register(BeanInfo{
+		Name:         "bean3",
+		Dependencies: []string{"bean2"},
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			return &bean3{}, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+
+	_, err = c.NewScope().Get("bean3")
+	t.Assertions.ErrorContains(err, "singleton bean3 cannot depend on scoped bean bean2")
+}
+
+func (t *scopeTestSuit) TestScopeString() {
+	t.Assertions.Equal("singleton", ScopeSingleton.String())
+	t.Assertions.Equal("prototype", ScopePrototype.String())
+	t.Assertions.Equal("scoped", ScopeScoped.String())
+}
@@ -0,0 +1,207 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Trace records the construction of a bean.
+type Trace struct {
+	Name string
+	// Trigger is the bean passed to the Get call that caused the
+	// construction.
+	Trigger string
+	// Dependencies are the names of the beans this one was built from.
+	Dependencies []string
+	Start        time.Time
+	End          time.Time
+	// Duration is End - Start, including the time spent resolving
+	// dependencies.
+	Duration time.Duration
+	// Self is the time spent constructing the bean once its dependencies
+	// were resolved.
+	Self time.Duration
+	Err  error
+}
+
+// TraceHook is called after every construction of a bean.
+type TraceHook func(Trace)
+
+// OnTrace adds a hook called with the trace of every bean created by c or
+// its children.
+func (c *Container) OnTrace(hook TraceHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traceHooks = append(c.traceHooks, hook)
+}
+
+// SlogTraceHook returns a TraceHook logging every construction to logger,
+// failed ones at error level.
+func SlogTraceHook(logger *slog.Logger) TraceHook {
+	return func(t Trace) {
+		attrs := []slog.Attr{
+			slog.String("bean", t.Name),
+			slog.String("trigger", t.Trigger),
+			slog.Duration("duration", t.Duration),
+			slog.Duration("self", t.Self),
+		}
+		if t.Err != nil {
+			attrs = append(attrs, slog.Any("error", t.Err))
+			logger.LogAttrs(context.Background(), slog.LevelError, "bean construction failed", attrs...)
+			return
+		}
+		logger.LogAttrs(context.Background(), slog.LevelInfo, "bean constructed", attrs...)
+	}
+}
+
+// trace records t in c and passes it to the trace hooks of c and its
+// parents.
+func (c *Container) trace(t Trace) {
+	c.mu.Lock()
+	if c.traces == nil {
+		c.traces = make(map[string]Trace)
+	}
+	c.traces[t.Name] = t
+	var hooks []TraceHook
+	for x := c; x != nil; x = x.parent {
+		hooks = append(slices.Clone(x.traceHooks), hooks...)
+	}
+	c.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(t)
+	}
+}
+
+// Traces returns the last construction of every bean created by c or its
+// parents, sorted by start time.
+func (c *Container) Traces() []Trace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	traces := make(map[string]Trace)
+	for x := c; x != nil; x = x.parent {
+		for name, t := range x.traces {
+			if _, ok := traces[name]; !ok {
+				traces[name] = t
+			}
+		}
+	}
+	return slices.SortedFunc(maps.Values(traces), func(a, b Trace) int {
+		if n := a.Start.Compare(b.Start); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// TraceReport summarizes the constructions of a container.
+type TraceReport struct {
+	// Traces are sorted by start time.
+	Traces []Trace
+	Start  time.Time
+	End    time.Time
+	// CriticalPath is the chain of beans, each depending on the next, with
+	// the largest total Self time: startup cannot be faster than it, however
+	// parallel.
+	CriticalPath []Trace
+}
+
+// TraceReport returns the report of the constructions of c, see Traces.
+func (c *Container) TraceReport() TraceReport {
+	return NewTraceReport(c.Traces())
+}
+
+// NewTraceReport returns the report of traces.
+func NewTraceReport(traces []Trace) TraceReport {
+	r := TraceReport{Traces: traces}
+	if len(traces) == 0 {
+		return r
+	}
+
+	byName := make(map[string]Trace, len(traces))
+	r.Start = traces[0].Start
+	for _, t := range traces {
+		byName[t.Name] = t
+		if t.Start.Before(r.Start) {
+			r.Start = t.Start
+		}
+		if t.End.After(r.End) {
+			r.End = t.End
+		}
+	}
+
+	// cost is the largest total Self time of a chain starting at a bean
+	cost := make(map[string]time.Duration, len(traces))
+	next := make(map[string]string, len(traces))
+	var visit func(t Trace) time.Duration
+	visit = func(t Trace) time.Duration {
+		if d, ok := cost[t.Name]; ok {
+			return d
+		}
+		cost[t.Name] = t.Self
+		var longest time.Duration
+		for _, dep := range t.Dependencies {
+			if d, ok := byName[dep]; ok {
+				if n := visit(d); n > longest {
+					longest, next[t.Name] = n, dep
+				}
+			}
+		}
+		cost[t.Name] = t.Self + longest
+		return cost[t.Name]
+	}
+	var first string
+	for _, t := range traces {
+		if d := visit(t); first == "" || d > cost[first] {
+			first = t.Name
+		}
+	}
+	for name := first; name != ""; name = next[name] {
+		r.CriticalPath = append(r.CriticalPath, byName[name])
+	}
+	return r
+}
+
+// Duration is the time from the first construction start to the last end.
+func (r TraceReport) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Timeline renders the constructions as a text chart, one line per bean in
+// start order, marking the beans of the critical path with a '*'.
+func (r TraceReport) Timeline() string {
+	const width = 40
+
+	var sb strings.Builder
+	names := make([]string, len(r.CriticalPath))
+	for i, t := range r.CriticalPath {
+		names[i] = t.Name
+	}
+	fmt.Fprintf(&sb, "%d beans in %s, critical path: %s\n", len(r.Traces), r.Duration(), strings.Join(names, " <- "))
+
+	total := r.Duration()
+	for _, t := range r.Traces {
+		from, to := 0, width
+		if total > 0 {
+			from = int(int64(width) * int64(t.Start.Sub(r.Start)) / int64(total))
+			to = int(int64(width) * int64(t.End.Sub(r.Start)) / int64(total))
+		}
+		bar := strings.Repeat(" ", from) + strings.Repeat("#", max(to-from, 1))
+		mark := " "
+		if slices.Contains(names, t.Name) {
+			mark = "*"
+		}
+		fmt.Fprintf(&sb, "%s %-*s %10s %10s  %s", mark, width, bar, t.Start.Sub(r.Start), t.Duration, t.Name)
+		if t.Err != nil {
+			fmt.Fprintf(&sb, " (%v)", t.Err)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
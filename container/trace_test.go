@@ -0,0 +1,118 @@
+package container
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type traceTestSuit struct {
+	suite.Suite
+}
+
+func TestTrace(t *testing.T) {
+	suite.Run(t, new(traceTestSuit))
+}
+
+func (t *traceTestSuit) register(c *Container, name string, sleep time.Duration, err error, deps ...string) {
+	t.Assertions.NoError(c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: deps,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			time.Sleep(sleep)
+			return name, err
+		},
+	}))
+}
+
+func (t *traceTestSuit) TestTraces() {
+	c := New()
+	t.register(c, "config", 0, nil)
+	t.register(c, "db", 20*time.Millisecond, nil, "config")
+	t.register(c, "cache", 0, nil, "config")
+	t.register(c, "service", 10*time.Millisecond, nil, "db", "cache")
+
+	var mu sync.Mutex
+	var hooked []string
+	c.OnTrace(func(trace Trace) {
+		mu.Lock()
+		defer mu.Unlock()
+		hooked = append(hooked, trace.Name)
+	})
+
+	_, err := c.Get("service")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal([]string{"config", "db", "cache", "service"}, hooked)
+
+	traces := c.Traces()
+	t.Assertions.Len(traces, 4)
+	t.Assertions.Equal("service", traces[0].Name, "traces should be sorted by start")
+	for _, trace := range traces {
+		t.Assertions.Equal("service", trace.Trigger, "every bean should be triggered by the Get call")
+		t.Assertions.Equal(trace.End.Sub(trace.Start), trace.Duration)
+		t.Assertions.LessOrEqual(trace.Self, trace.Duration)
+	}
+	t.Assertions.Equal([]string{"db", "cache"}, traces[0].Dependencies)
+	t.Assertions.GreaterOrEqual(traces[0].Duration, 30*time.Millisecond)
+	t.Assertions.Less(traces[0].Self, 20*time.Millisecond, "Self should not include dependencies")
+
+	report := c.TraceReport()
+	var path []string
+	for _, trace := range report.CriticalPath {
+		path = append(path, trace.Name)
+	}
+	t.Assertions.Equal([]string{"service", "db", "config"}, path)
+	t.Assertions.Equal(traces[0].Duration, report.Duration())
+
+	timeline := report.Timeline()
+	t.Assertions.Contains(timeline, "4 beans in")
+	t.Assertions.Contains(timeline, "critical path: service <- db <- config")
+	t.Assertions.Regexp(`\* #+ +\S+ +\S+  service\n`, timeline)
+	t.Assertions.Regexp(`\n  +#+ +\S+ +\S+  cache\n`, timeline)
+}
+
+func (t *traceTestSuit) TestFailed() {
+	c := New()
+	t.register(c, "db", 0, errors.New("boom"))
+
+	var buf bytes.Buffer
+	c.OnTrace(SlogTraceHook(slog.New(slog.NewTextHandler(&buf, nil))))
+	_, err := c.Get("db")
+	t.Assertions.Error(err, "Get() should return error")
+
+	traces := c.Traces()
+	t.Assertions.Len(traces, 1)
+	t.Assertions.EqualError(traces[0].Err, "boom")
+	t.Assertions.Contains(buf.String(), `level=ERROR msg="bean construction failed" bean=db trigger=db`)
+	t.Assertions.Contains(buf.String(), "error=boom")
+	t.Assertions.Contains(c.TraceReport().Timeline(), "db (boom)")
+}
+
+func (t *traceTestSuit) TestChild() {
+	c := New()
+	t.register(c, "db", 0, nil)
+	child := c.NewChild()
+	t.register(child, "repo", 0, nil, "db")
+
+	var parentHooked, childHooked []string
+	c.OnTrace(func(trace Trace) { parentHooked = append(parentHooked, trace.Name) })
+	child.OnTrace(func(trace Trace) { childHooked = append(childHooked, trace.Name) })
+
+	_, err := child.Get("repo")
+	t.Assertions.NoError(err, "Get() should not return error")
+	t.Assertions.Equal([]string{"db", "repo"}, parentHooked, "parent hooks should see beans of children")
+	t.Assertions.Equal([]string{"repo"}, childHooked, "child hooks should not see beans of the parent")
+	t.Assertions.Len(child.Traces(), 2)
+	t.Assertions.Len(c.Traces(), 1)
+}
+
+func (t *traceTestSuit) TestEmptyReport() {
+	report := New().TraceReport()
+	t.Assertions.Empty(report.CriticalPath)
+	t.Assertions.Equal("0 beans in 0s, critical path: \n", report.Timeline())
+}
@@ -0,0 +1,208 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Validate checks the dependency graph of the beans visible from c without
+// creating any bean. It reports every dependency that cannot be resolved and
+// every cycle, with its full path, as one joined error. Lazy dependencies
+// must exist but do not count for cycles.
+func (c *Container) Validate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	names := c.names()
+	edges := make(map[string][]string, len(names))
+	for _, name := range names {
+		owner, beanInfo, err := c.find(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		holder := c.holder(owner, beanInfo)
+		for _, dep := range holder.dependencies(beanInfo) {
+			depInfo, err := holder.lookup(dep)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("bean %s depends on %s: %w", name, dep, err))
+				continue
+			}
+			edges[name] = append(edges[name], depInfo.Name)
+		}
+		if beanInfo.Scope == ScopeSingleton {
+			for _, path := range holder.scopedDependencies([]string{name}, beanInfo, make(map[string]bool)) {
+				dep := path[len(path)-1]
+				errs = append(errs, &Error{Kind: ErrScope, Name: dep, Path: path, msg: fmt.Sprintf("singleton %s cannot depend on scoped bean %s", name, dep)})
+			}
+		}
+		for _, dep := range beanInfo.LazyDependencies {
+			if _, err := holder.lookup(dep); err != nil {
+				errs = append(errs, fmt.Errorf("bean %s depends lazily on %s: %w", name, dep, err))
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(names, edges) {
+		errs = append(errs, &Error{Kind: ErrCircular, Name: cycle[0], Path: cycle, msg: "circular dependency: " + strings.Join(cycle, " -> ")})
+	}
+	return errors.Join(errs...)
+}
+
+// scopedDependencies returns the paths from path to the scoped beans that
+// beanInfo, at the end of path, depends on directly or through prototypes,
+// which are created for the bean depending on them. Must be called with c.mu
+// held.
+func (c *Container) scopedDependencies(path []string, beanInfo BeanInfo, visited map[string]bool) [][]string {
+	var paths [][]string
+	for _, dep := range c.dependencies(beanInfo) {
+		depInfo, err := c.lookup(dep)
+		if err != nil || visited[depInfo.Name] {
+			continue
+		}
+		visited[depInfo.Name] = true
+		depPath := append(slices.Clone(path), depInfo.Name)
+		switch depInfo.Scope {
+		case ScopeScoped:
+			paths = append(paths, depPath)
+		case ScopePrototype:
+			paths = append(paths, c.scopedDependencies(depPath, depInfo, visited)...)
+		}
+	}
+	return paths
+}
+
+// dependencies lists the names a bean needs: its Dependencies and existing
+// OptionalDependencies, or their fallbacks, the members of its
+// GroupDependencies and, when its Type is known, the names of its required
+// `inject` fields. Must be called with c.mu held.
+func (c *Container) dependencies(beanInfo BeanInfo) []string {
+	var deps []string
+	for _, dep := range slices.Concat(beanInfo.Dependencies, beanInfo.OptionalDependencies) {
+		if name, ok := c.dependency(beanInfo, dep); ok {
+			deps = append(deps, name)
+		}
+	}
+	for _, group := range beanInfo.GroupDependencies {
+		deps = append(deps, c.group(group)...)
+	}
+	if !beanInfo.InjectFields || beanInfo.Type == nil {
+		return deps
+	}
+	t := beanInfo.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return deps
+	}
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("inject")
+		if !ok {
+			continue
+		}
+		// invalid tags are rejected by Register
+		name, optional, err := injectTag(t.Field(i), tag)
+		if err != nil {
+			continue
+		}
+		if optional {
+			if _, err := c.lookup(name); errors.Is(err, ErrNotFound) {
+				continue
+			}
+		}
+		deps = append(deps, name)
+	}
+	return deps
+}
+
+// dependency returns the name to resolve for the dependency dep of
+// beanInfo: dep if it exists, else its fallback if any. It reports false for
+// a missing optional dependency without fallback. Must be called with c.mu
+// held.
+func (c *Container) dependency(beanInfo BeanInfo, dep string) (string, bool) {
+	if _, err := c.lookup(dep); err == nil || !errors.Is(err, ErrNotFound) {
+		return dep, true
+	}
+	if fallback, ok := beanInfo.Fallbacks[dep]; ok {
+		return fallback, true
+	}
+	return dep, !slices.Contains(beanInfo.OptionalDependencies, dep)
+}
+
+// findCycles returns every elementary cycle of the graph, each as a path
+// starting and ending with its smallest name, using Johnson's algorithm.
+// names must be sorted.
+func findCycles(names []string, edges map[string][]string) [][]string {
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	seen := make(map[string]struct{})
+	var cycles [][]string
+
+	// look for the cycles through start among the names not before it
+	for first, start := range names {
+		inGraph := func(name string) bool {
+			i, ok := index[name]
+			return ok && i >= first
+		}
+		blocked := make(map[string]bool)
+		blockers := make(map[string]map[string]struct{})
+		var stack []string
+
+		var unblock func(name string)
+		unblock = func(name string) {
+			blocked[name] = false
+			for w := range blockers[name] {
+				delete(blockers[name], w)
+				if blocked[w] {
+					unblock(w)
+				}
+			}
+		}
+
+		var circuit func(name string) bool
+		circuit = func(name string) bool {
+			found := false
+			stack = append(stack, name)
+			blocked[name] = true
+			for _, dep := range edges[name] {
+				if !inGraph(dep) {
+					continue
+				}
+				if dep == start {
+					cycle := append(slices.Clone(stack), start)
+					key := strings.Join(cycle, "\x00")
+					if _, ok := seen[key]; !ok {
+						seen[key] = struct{}{}
+						cycles = append(cycles, cycle)
+					}
+					found = true
+				} else if !blocked[dep] && circuit(dep) {
+					found = true
+				}
+			}
+			if found {
+				unblock(name)
+			} else {
+				for _, dep := range edges[name] {
+					if inGraph(dep) {
+						if blockers[dep] == nil {
+							blockers[dep] = make(map[string]struct{})
+						}
+						blockers[dep][name] = struct{}{}
+					}
+				}
+			}
+			stack = stack[:len(stack)-1]
+			return found
+		}
+		circuit(start)
+	}
+	return cycles
+}
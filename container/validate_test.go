@@ -0,0 +1,102 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type validateTestSuit struct {
+	suite.Suite
+}
+
+func TestValidate(t *testing.T) {
+	suite.Run(t, new(validateTestSuit))
+}
+
+func (t *validateTestSuit) register(c *Container, name string, scope Scope, depends ...string) {
+	err := c.Register(BeanInfo{
+		Name:         name,
+		Dependencies: depends,
+		Scope:        scope,
+		Constructor: func(depends map[string]any, params map[string]any) (interface{}, error) {
+			t.Fail("Validate() should not call constructors")
+			return nil, nil
+		},
+	})
+	t.Assertions.NoError(err, "Register() should not return error")
+}
+
+func (t *validateTestSuit) TestValid() {
+	c := New()
+	t.register(c, "a", ScopeSingleton, "b", "c")
+	t.register(c, "b", ScopeSingleton, "c")
+	t.register(c, "c", ScopeSingleton)
+	t.Assertions.NoError(c.Validate(), "Validate() should not return error")
+}
+
+func (t *validateTestSuit) TestValidateReportsEverything() {
+	c := New()
+	t.register(c, "a", ScopeSingleton, "b", "missing1")
+	t.register(c, "b", ScopeSingleton, "c")
+	t.register(c, "c", ScopeSingleton, "a")
+	t.register(c, "d", ScopeSingleton, "d")
+	t.register(c, "e", ScopeSingleton, "missing2", "f")
+	t.register(c, "f", ScopeScoped)
+
+	err := c.Validate()
+	t.Assertions.Error(err, "Validate() should return error")
+	t.Assertions.ErrorContains(err, "bean a depends on missing1: bean not found: missing1")
+	t.Assertions.ErrorContains(err, "bean e depends on missing2: bean not found: missing2")
+	t.Assertions.ErrorContains(err, "circular dependency: a -> b -> c -> a")
+	t.Assertions.ErrorContains(err, "circular dependency: d -> d")
+	t.Assertions.ErrorContains(err, "singleton e cannot depend on scoped bean f")
+}
+
+func (t *validateTestSuit) TestValidateScopeThroughPrototype() {
+	c := New()
+	t.register(c, "a", ScopeSingleton, "b")
+	t.register(c, "b", ScopePrototype, "c", "d")
+	t.register(c, "c", ScopePrototype, "d")
+	t.register(c, "d", ScopeScoped)
+
+	err := c.Validate()
+	t.Assertions.EqualError(err, "singleton a cannot depend on scoped bean d")
+	var e *Error
+	t.Assertions.ErrorAs(err, &e)
+	t.Assertions.ErrorIs(e, ErrScope)
+	t.Assertions.Equal([]string{"a", "b", "c", "d"}, e.Path)
+
+	_, err = c.NewScope().Get("a")
+	t.Assertions.ErrorIs(err, ErrScope, "Get() should fail as Validate() reports")
+}
+
+func (t *validateTestSuit) TestValidateCycleReportedOnce() {
+	c := New()
+	t.register(c, "x", ScopeSingleton, "b")
+	t.register(c, "b", ScopeSingleton, "a")
+	t.register(c, "a", ScopeSingleton, "b")
+
+	err := c.Validate()
+	t.Assertions.EqualError(err, "circular dependency: a -> b -> a")
+}
+
+func (t *validateTestSuit) TestValidateEveryCycle() {
+	c := New()
+	t.register(c, "a", ScopeSingleton, "b", "c")
+	t.register(c, "b", ScopeSingleton, "a")
+	t.register(c, "c", ScopeSingleton, "b")
+
+	err := c.Validate()
+	t.Assertions.EqualError(err, "circular dependency: a -> b -> a\ncircular dependency: a -> c -> b -> a")
+}
+
+func (t *validateTestSuit) TestValidateInjectFields() {
+	c := New()
+	t.Assertions.NoError(c.Provide(func() *handler { return &handler{} }, WithInjectFields()), "Provide() should not return error")
+
+	err := c.Validate()
+	t.Assertions.ErrorContains(err, "depends on *github.com/0x0001/halo/container.db")
+	t.Assertions.ErrorContains(err, "depends on cache")
+	t.Assertions.NotContains(err.Error(), "missing", "optional fields should not be reported")
+}